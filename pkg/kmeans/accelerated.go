@@ -0,0 +1,279 @@
+package kmeans
+
+import (
+	"context"
+	"math"
+)
+
+// boundSlack is the relative safety margin applied before pruning with a bound.
+// It absorbs the rounding errors accumulated by the bound updates, so a centroid is only
+// skipped when it is certainly farther away and the result stays exactly equal to Lloyd's.
+const boundSlack = 1e-9
+
+// certainlyCloser reports whether a point at most upper away from its centroid is
+// strictly closer to it than to any centroid at least bound away.
+func certainlyCloser(upper, bound float64) bool {
+	return upper < bound*(1-boundSlack)
+}
+
+// halfNearestSeparation returns, for every centroid, half the Euclidean distance to its nearest
+// other centroid. A point closer to its centroid than that cannot be closer to any other one.
+func halfNearestSeparation(centroids []Point) []float64 {
+	halfNearest := make([]float64, len(centroids))
+	for j := range halfNearest {
+		halfNearest[j] = math.Inf(1)
+	}
+
+	for a := range centroids {
+		for b := a + 1; b < len(centroids); b++ {
+			h := distance(centroids[a], centroids[b]) / 2
+			halfNearest[a] = min(halfNearest[a], h)
+			halfNearest[b] = min(halfNearest[b], h)
+		}
+	}
+
+	return halfNearest
+}
+
+// halfSeparation returns half the Euclidean distance between every pair of centroids.
+func halfSeparation(centroids []Point) [][]float64 {
+	half := make([][]float64, len(centroids))
+	for j := range half {
+		half[j] = make([]float64, len(centroids))
+	}
+
+	for a := range centroids {
+		for b := a + 1; b < len(centroids); b++ {
+			h := distance(centroids[a], centroids[b]) / 2
+			half[a][b], half[b][a] = h, h
+		}
+	}
+
+	return half
+}
+
+// centroidShifts returns the Euclidean distance every centroid moved in the last update.
+func centroidShifts(previous, centroids []Point) []float64 {
+	shifts := make([]float64, len(centroids))
+	for j := range centroids {
+		shifts[j] = distance(previous[j], centroids[j])
+	}
+
+	return shifts
+}
+
+// elkan is the assignment step of Elkan's algorithm ("Using the Triangle Inequality to
+// Accelerate k-Means"). For every point it keeps an upper bound of the distance to its
+// centroid and a lower bound of the distance to every centroid, using n*k memory.
+type elkan struct {
+	points      []Point
+	assignments []int
+	cfg         *config
+	scale       func(float64) float64 // converts metric distances to Euclidean units
+	upper       []float64             // upper bound of the distance to the assigned centroid
+	lower       [][]float64           // lower bounds of the distances to every centroid
+}
+
+func newElkan(points []Point, assignments []int, cfg *config) *elkan {
+	return &elkan{points: points, assignments: assignments, cfg: cfg, scale: euclideanScale(cfg.metric)}
+}
+
+func (e *elkan) assign(ctx context.Context, centroids []Point) (bool, error) {
+	if e.upper == nil {
+		return e.initialize(ctx, centroids)
+	}
+
+	half := halfSeparation(centroids)
+	halfNearest := halfNearestSeparation(centroids)
+
+	return parallelPass(ctx, len(e.points), e.cfg.workers, func(i int) bool {
+		return e.assignPoint(i, centroids, half, halfNearest)
+	})
+}
+
+// initialize computes every distance once, which sets all bounds to exact values.
+func (e *elkan) initialize(ctx context.Context, centroids []Point) (bool, error) {
+	k := len(centroids)
+	flat := make([]float64, len(e.points)*k)
+	e.upper = make([]float64, len(e.points))
+	e.lower = make([][]float64, len(e.points))
+
+	return parallelPass(ctx, len(e.points), e.cfg.workers, func(i int) bool {
+		lower := flat[i*k : (i+1)*k]
+		best, bestDist := 0, 0.0
+		for j, centroid := range centroids {
+			d := e.cfg.metric.Distance(e.points[i], centroid)
+			lower[j] = e.scale(d)
+			if j == 0 || d < bestDist {
+				best, bestDist = j, d
+			}
+		}
+
+		e.lower[i] = lower
+		e.upper[i] = lower[best]
+		changed := e.assignments[i] != best
+		e.assignments[i] = best
+
+		return changed
+	})
+}
+
+// assignPoint finds the nearest centroid of point i, computing only the distances the bounds
+// cannot rule out. Ties go to the lowest index, exactly as in nearestCentroid.
+func (e *elkan) assignPoint(i int, centroids []Point, half [][]float64, halfNearest []float64) bool {
+	current := e.assignments[i]
+	upper := e.upper[i]
+	if certainlyCloser(upper, halfNearest[current]) {
+		return false
+	}
+
+	point, lower := e.points[i], e.lower[i]
+	best, bestDist, tight := current, 0.0, false
+	for j, centroid := range centroids {
+		if j == best || certainlyCloser(upper, max(lower[j], half[best][j])) {
+			continue
+		}
+
+		if !tight {
+			// Tighten the upper bound once per pass before computing other distances
+			bestDist = e.cfg.metric.Distance(point, centroids[best])
+			upper = e.scale(bestDist)
+			lower[best] = upper
+			tight = true
+			if certainlyCloser(upper, max(lower[j], half[best][j])) {
+				continue
+			}
+		}
+
+		d := e.cfg.metric.Distance(point, centroid)
+		lower[j] = e.scale(d)
+		if d < bestDist || (d == bestDist && j < best) {
+			best, bestDist, upper = j, d, lower[j]
+		}
+	}
+
+	e.upper[i] = upper
+	e.assignments[i] = best
+
+	return best != current
+}
+
+// moved loosens the bounds by how far each centroid moved.
+func (e *elkan) moved(previous, centroids []Point) {
+	shifts := centroidShifts(previous, centroids)
+
+	_, _ = parallelPass(context.Background(), len(e.points), e.cfg.workers, func(i int) bool {
+		e.upper[i] += shifts[e.assignments[i]]
+		lower := e.lower[i]
+		for j, shift := range shifts {
+			lower[j] = max(0, lower[j]-shift)
+		}
+
+		return false
+	})
+}
+
+// reassigned drops the upper bound of a point moved by the empty-cluster handling,
+// so the next pass recomputes its distances.
+func (e *elkan) reassigned(i int) {
+	e.upper[i] = math.Inf(1)
+}
+
+// hamerly is the assignment step of Hamerly's algorithm ("Making k-means Even Faster").
+// For every point it keeps an upper bound of the distance to its centroid and a single
+// lower bound of the distance to the second closest centroid.
+type hamerly struct {
+	points      []Point
+	assignments []int
+	cfg         *config
+	scale       func(float64) float64 // converts metric distances to Euclidean units
+	upper       []float64             // upper bound of the distance to the assigned centroid
+	lower       []float64             // lower bound of the distance to the second closest centroid
+}
+
+func newHamerly(points []Point, assignments []int, cfg *config) *hamerly {
+	return &hamerly{points: points, assignments: assignments, cfg: cfg, scale: euclideanScale(cfg.metric)}
+}
+
+func (h *hamerly) assign(ctx context.Context, centroids []Point) (bool, error) {
+	first := h.upper == nil
+	if first {
+		h.upper = make([]float64, len(h.points))
+		h.lower = make([]float64, len(h.points))
+	}
+
+	halfNearest := halfNearestSeparation(centroids)
+
+	return parallelPass(ctx, len(h.points), h.cfg.workers, func(i int) bool {
+		if !first {
+			current := h.assignments[i]
+			bound := max(halfNearest[current], h.lower[i])
+			if certainlyCloser(h.upper[i], bound) {
+				return false
+			}
+
+			// Tighten the upper bound and try again before scanning all centroids
+			h.upper[i] = h.scale(h.cfg.metric.Distance(h.points[i], centroids[current]))
+			if certainlyCloser(h.upper[i], bound) {
+				return false
+			}
+		}
+
+		best, bestDist, secondDist := h.scan(h.points[i], centroids)
+		h.upper[i], h.lower[i] = h.scale(bestDist), h.scale(secondDist)
+		changed := h.assignments[i] != best
+		h.assignments[i] = best
+
+		return changed
+	})
+}
+
+// scan compares the point with every centroid and returns the nearest one (lowest index on ties,
+// as in nearestCentroid) together with the distances to the closest and second closest centroid.
+func (h *hamerly) scan(point Point, centroids []Point) (int, float64, float64) {
+	best, bestDist, secondDist := 0, h.cfg.metric.Distance(point, centroids[0]), math.Inf(1)
+
+	for j := 1; j < len(centroids); j++ {
+		d := h.cfg.metric.Distance(point, centroids[j])
+		if d < bestDist {
+			best, bestDist, secondDist = j, d, bestDist
+		} else if d < secondDist {
+			secondDist = d
+		}
+	}
+
+	return best, bestDist, secondDist
+}
+
+// moved loosens the bounds: the upper bound by the shift of the assigned centroid,
+// the lower bound by the largest shift among the other centroids.
+func (h *hamerly) moved(previous, centroids []Point) {
+	shifts := centroidShifts(previous, centroids)
+	largest, largestIndex, secondLargest := 0.0, -1, 0.0
+	for j, shift := range shifts {
+		if shift > largest {
+			largest, largestIndex, secondLargest = shift, j, largest
+		} else if shift > secondLargest {
+			secondLargest = shift
+		}
+	}
+
+	_, _ = parallelPass(context.Background(), len(h.points), h.cfg.workers, func(i int) bool {
+		current := h.assignments[i]
+		h.upper[i] += shifts[current]
+		if current == largestIndex {
+			h.lower[i] -= secondLargest
+		} else {
+			h.lower[i] -= largest
+		}
+
+		return false
+	})
+}
+
+// reassigned drops the bounds of a point moved by the empty-cluster handling,
+// so the next pass scans all centroids for it.
+func (h *hamerly) reassigned(i int) {
+	h.upper[i] = math.Inf(1)
+	h.lower[i] = 0
+}
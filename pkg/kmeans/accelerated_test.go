@@ -0,0 +1,77 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type AcceleratedSuite struct {
+	suite.Suite
+}
+
+func TestAcceleratedSuite(t *testing.T) {
+	suite.Run(t, new(AcceleratedSuite))
+}
+
+// grid returns the integer points of a size x size square; equidistant centroids are frequent on it.
+func grid(size int) []kmeans.Point {
+	points := make([]kmeans.Point, 0, size*size)
+	for x := range size {
+		for y := range size {
+			points = append(points, kmeans.Point{float64(x), float64(y)})
+		}
+	}
+	return points
+}
+
+// assertSameAsLloyd runs Lloyd, Elkan and Hamerly with the same options and requires identical results.
+func (s *AcceleratedSuite) assertSameAsLloyd(points []kmeans.Point, opts ...kmeans.Option) {
+	lloyd, err := kmeans.Fit(points, opts...)
+	s.Require().NoError(err)
+
+	for _, algorithm := range []kmeans.Algorithm{kmeans.Elkan, kmeans.Hamerly} {
+		accelerated, err := kmeans.Fit(points, append(opts, kmeans.WithAlgorithm(algorithm))...)
+		s.Require().NoError(err)
+
+		s.Equal(lloyd.Centroids, accelerated.Centroids, algorithm.String())
+		s.Equal(lloyd.Assignments, accelerated.Assignments, algorithm.String())
+		s.Equal(lloyd.Iterations, accelerated.Iterations, algorithm.String())
+		s.Equal(lloyd.StopReason, accelerated.StopReason, algorithm.String())
+		s.Equal(lloyd.SSE, accelerated.SSE, algorithm.String())
+	}
+}
+
+func (s *AcceleratedSuite) TestUniformDataMatchesLloyd() {
+	s.assertSameAsLloyd(randomPoints(2000, 4, 21), kmeans.WithK(20), kmeans.WithSeed(2))
+}
+
+func (s *AcceleratedSuite) TestBlobsMatchLloyd() {
+	centers := []kmeans.Point{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}, {10, 10, 10}}
+	s.assertSameAsLloyd(gaussianBlobs(centers, 300, 2, 4), kmeans.WithK(8), kmeans.WithSeed(6))
+}
+
+func (s *AcceleratedSuite) TestTiesMatchLloyd() {
+	s.assertSameAsLloyd(grid(12), kmeans.WithK(9),
+		kmeans.WithSeed(1), kmeans.WithSeededInitializer(kmeans.SeededRandomCentroids))
+}
+
+func (s *AcceleratedSuite) TestSquaredEuclideanMatchesLloyd() {
+	s.assertSameAsLloyd(randomPoints(1500, 3, 8),
+		kmeans.WithK(12), kmeans.WithSeed(3), kmeans.WithMetric(kmeans.SquaredEuclidean))
+}
+
+func (s *AcceleratedSuite) TestParallelMatchesLloyd() {
+	s.assertSameAsLloyd(randomPoints(4000, 2, 13), kmeans.WithK(16), kmeans.WithSeed(4), kmeans.WithWorkers(4))
+}
+
+func (s *AcceleratedSuite) TestNonEuclideanMetricReturnsError() {
+	_, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2),
+		kmeans.WithAlgorithm(kmeans.Elkan), kmeans.WithMetric(kmeans.Manhattan))
+	s.ErrorIs(err, kmeans.ErrMetricNotSupported)
+
+	_, err = kmeans.Fit(twoBlobs(), kmeans.WithK(2),
+		kmeans.WithAlgorithm(kmeans.Hamerly), kmeans.WithMetric(kmeans.Minkowski(3)))
+	s.ErrorIs(err, kmeans.ErrMetricNotSupported)
+}
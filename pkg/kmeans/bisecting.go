@@ -0,0 +1,192 @@
+package kmeans
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SplitCriterion selects the cluster BisectingKMeans splits next.
+type SplitCriterion int
+
+const (
+	// LargestSSE splits the cluster with the largest sum of squared errors.
+	LargestSSE SplitCriterion = iota
+	// LargestSize splits the cluster with the most points, which gives more balanced clusters.
+	LargestSize
+)
+
+// String returns a human-readable name of the split criterion.
+func (c SplitCriterion) String() string {
+	switch c {
+	case LargestSSE:
+		return "largest SSE"
+	case LargestSize:
+		return "largest size"
+	default:
+		return fmt.Sprintf("SplitCriterion(%d)", int(c))
+	}
+}
+
+// BisectingNode is a cluster of the split tree built by BisectingKMeans.
+// Inner nodes were split in two; the leaves are the final clusters.
+type BisectingNode struct {
+	Centroid Point          // mean of the points of the cluster
+	Indices  []int          // dataset indices of the points of the cluster
+	SSE      float64        // sum of squared errors of the points to the centroid
+	Cluster  int            // index of the final cluster for a leaf, -1 for an inner node
+	Left     *BisectingNode // first half of the split; nil for a leaf
+	Right    *BisectingNode // second half of the split; nil for a leaf
+}
+
+// BisectingResult describes the outcome of BisectingKMeans.
+type BisectingResult struct {
+	Centroids    []Point        // final positions of the cluster centroids, the leaves from left to right
+	Assignments  []int          // index of the centroid assigned to each point
+	SSE          float64        // within-cluster sum of squared errors of the final partition
+	ClusterSizes []int          // number of points assigned to each cluster
+	Tree         *BisectingNode // binary split tree; its root holds every point
+}
+
+// BisectingKMeans performs divisive clustering: it starts with all points in one cluster and
+// repeatedly splits the cluster chosen by criterion in two with 2-means, until k clusters exist.
+// Every split is a Fit run with k=2 seeded by k-means++ (SmartCentroids), or the initializer set in opts.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - k: the number of clusters to form
+//   - criterion: LargestSSE or LargestSize
+//   - opts: options of every 2-means run; WithK and WithEmptyClusterStrategy are ignored
+//
+// Returns:
+//   - result: centroids, assignments and the binary split tree
+//   - err: one of the Err* validation errors; ErrNotEnoughDistinctPoints if the points
+//     cannot be split into k non-empty clusters
+//
+// Clusters whose points all coincide are never split. The result is reproducible with WithSeed.
+func BisectingKMeans(points []Point, k int, criterion SplitCriterion, opts ...Option) (*BisectingResult, error) {
+	cfg := newConfig(append(slices.Clone(opts), WithK(k)))
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.rejectWeights("bisecting k-means"); err != nil {
+		return nil, err
+	}
+
+	if !cfg.metric.MeanCentroid() {
+		return nil, fmt.Errorf("%w: %s", ErrMetricNotMeanCompatible, metricName(cfg.metric))
+	}
+
+	if criterion < LargestSSE || criterion > LargestSize {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSplitCriterion, criterion)
+	}
+
+	// An empty half would leave a cluster without points, so the 2-means runs always reseed it
+	halving := append(slices.Clone(opts), WithEmptyClusterStrategy(ReseedFarthestPoint))
+	b := &bisection{points: points, metric: cfg.metric, splitter: splitter{opts: halving, rng: cfg.rng}}
+	all := make([]int, len(points))
+	for i := range all {
+		all[i] = i
+	}
+
+	root := b.node(all, mean(points))
+	leaves := []*BisectingNode{root}
+	for len(leaves) < k {
+		j := nextToSplit(leaves, criterion)
+		if j < 0 {
+			return nil, fmt.Errorf("%w: only %d clusters can be formed", ErrNotEnoughDistinctPoints, len(leaves))
+		}
+
+		if err := b.split(leaves[j]); err != nil {
+			return nil, err
+		}
+		leaves = slices.Replace(leaves, j, j+1, leaves[j].Left, leaves[j].Right)
+	}
+
+	return b.result(root, leaves), nil
+}
+
+// bisection holds the state shared by the splits of BisectingKMeans.
+type bisection struct {
+	splitter
+	points []Point
+	metric Metric
+}
+
+// node returns a leaf holding the points with the given indices around centroid.
+func (b *bisection) node(indices []int, centroid Point) *BisectingNode {
+	sse := 0.0
+	for _, i := range indices {
+		sse += squaredError(b.metric, b.points[i], centroid)
+	}
+
+	return &BisectingNode{Centroid: centroid, Indices: indices, SSE: sse, Cluster: -1}
+}
+
+// split runs 2-means on the points of the leaf and attaches the two halves as its children.
+func (b *bisection) split(leaf *BisectingNode) error {
+	cluster := make([]Point, len(leaf.Indices))
+	for n, i := range leaf.Indices {
+		cluster[n] = b.points[i]
+	}
+
+	halves, err := b.fit(cluster, 2, nil)
+	if err != nil {
+		return fmt.Errorf("splitting a cluster of %d points: %w", len(cluster), err)
+	}
+
+	var left, right []int
+	for n, a := range halves.Assignments {
+		if a == 0 {
+			left = append(left, leaf.Indices[n])
+		} else {
+			right = append(right, leaf.Indices[n])
+		}
+	}
+
+	leaf.Left = b.node(left, halves.Centroids[0])
+	leaf.Right = b.node(right, halves.Centroids[1])
+
+	return nil
+}
+
+// result numbers the leaves from left to right and collects the final partition.
+func (b *bisection) result(root *BisectingNode, leaves []*BisectingNode) *BisectingResult {
+	result := &BisectingResult{
+		Centroids:    make([]Point, len(leaves)),
+		Assignments:  make([]int, len(b.points)),
+		ClusterSizes: make([]int, len(leaves)),
+		Tree:         root,
+	}
+
+	for j, leaf := range leaves {
+		leaf.Cluster = j
+		result.Centroids[j] = leaf.Centroid
+		result.ClusterSizes[j] = len(leaf.Indices)
+		result.SSE += leaf.SSE
+		for _, i := range leaf.Indices {
+			result.Assignments[i] = j
+		}
+	}
+
+	return result
+}
+
+// nextToSplit returns the index of the leaf to split next, or -1 if no leaf can be split.
+// Leaves with a single point or no spread cannot be split; ties go to the leftmost leaf.
+func nextToSplit(leaves []*BisectingNode, criterion SplitCriterion) int {
+	best := -1
+	for j, leaf := range leaves {
+		if len(leaf.Indices) < 2 || leaf.SSE == 0 {
+			continue
+		}
+
+		if best < 0 ||
+			(criterion == LargestSSE && leaf.SSE > leaves[best].SSE) ||
+			(criterion == LargestSize && len(leaf.Indices) > len(leaves[best].Indices)) {
+			best = j
+		}
+	}
+
+	return best
+}
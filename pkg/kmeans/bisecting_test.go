@@ -0,0 +1,146 @@
+package kmeans_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type BisectingSuite struct {
+	suite.Suite
+}
+
+func TestBisectingSuite(t *testing.T) {
+	suite.Run(t, new(BisectingSuite))
+}
+
+// countNodes returns the number of inner nodes and leaves of the tree.
+func countNodes(node *kmeans.BisectingNode) (int, int) {
+	if node.Left == nil {
+		return 0, 1
+	}
+
+	leftInner, leftLeaves := countNodes(node.Left)
+	rightInner, rightLeaves := countNodes(node.Right)
+
+	return leftInner + rightInner + 1, leftLeaves + rightLeaves
+}
+
+func (s *BisectingSuite) TestFindsFourBlobs() {
+	points := fourBlobs()
+
+	result, err := kmeans.BisectingKMeans(points, 4, kmeans.LargestSSE, kmeans.WithSeed(1))
+	s.Require().NoError(err)
+
+	s.Len(result.Centroids, 4)
+	s.Equal([]int{80, 80, 80, 80}, sortedSizes(result.ClusterSizes))
+	s.InDelta(kmeans.CalculateSSE(points, result.Centroids, result.Assignments), result.SSE, 1e-9)
+
+	inner, leaves := countNodes(result.Tree)
+	s.Equal(3, inner)
+	s.Equal(4, leaves)
+	s.Len(result.Tree.Indices, len(points))
+	s.Equal(-1, result.Tree.Cluster)
+}
+
+func (s *BisectingSuite) TestLeavesMatchClusters() {
+	points := fourBlobs()
+
+	result, err := kmeans.BisectingKMeans(points, 5, kmeans.LargestSize, kmeans.WithSeed(2))
+	s.Require().NoError(err)
+
+	var visit func(node *kmeans.BisectingNode)
+	visit = func(node *kmeans.BisectingNode) {
+		if node.Left == nil {
+			s.Equal(result.Centroids[node.Cluster], node.Centroid)
+			for _, i := range node.Indices {
+				s.Equal(node.Cluster, result.Assignments[i])
+			}
+			return
+		}
+
+		s.Equal(-1, node.Cluster)
+		s.Len(node.Indices, len(node.Left.Indices)+len(node.Right.Indices))
+		visit(node.Left)
+		visit(node.Right)
+	}
+	visit(result.Tree)
+}
+
+func (s *BisectingSuite) TestCriterionChoosesTheSplit() {
+	// A large tight group and a small wide one: the first split separates them,
+	// then LargestSize splits the tight group and LargestSSE the wide one.
+	tight := gaussianBlobs([]kmeans.Point{{0, 0}}, 100, 0.1, 1)
+	wide := gaussianBlobs([]kmeans.Point{{100, 0}}, 10, 5, 2)
+	points := append(tight, wide...)
+
+	bySize, err := kmeans.BisectingKMeans(points, 3, kmeans.LargestSize, kmeans.WithSeed(3))
+	s.Require().NoError(err)
+	bySSE, err := kmeans.BisectingKMeans(points, 3, kmeans.LargestSSE, kmeans.WithSeed(3))
+	s.Require().NoError(err)
+
+	s.Equal([]int{10, 100}, sortedSizes(childSizes(bySize.Tree)))
+	s.Equal([]int{10, 100}, sortedSizes(childSizes(bySSE.Tree)))
+
+	splitOfSize := bySize.Tree.Left
+	if splitOfSize.Left == nil {
+		splitOfSize = bySize.Tree.Right
+	}
+	s.Len(splitOfSize.Indices, 100)
+
+	splitOfSSE := bySSE.Tree.Left
+	if splitOfSSE.Left == nil {
+		splitOfSSE = bySSE.Tree.Right
+	}
+	s.Len(splitOfSSE.Indices, 10)
+}
+
+// sortedSizes returns the cluster sizes in increasing order.
+func sortedSizes(sizes []int) []int {
+	return slices.Sorted(slices.Values(sizes))
+}
+
+// childSizes returns the number of points of both children of a node.
+func childSizes(node *kmeans.BisectingNode) []int {
+	return []int{len(node.Left.Indices), len(node.Right.Indices)}
+}
+
+func (s *BisectingSuite) TestSingleCluster() {
+	points := twoBlobs()
+
+	result, err := kmeans.BisectingKMeans(points, 1, kmeans.LargestSSE)
+	s.Require().NoError(err)
+
+	s.Equal([]int{0, 0, 0, 0, 0, 0}, result.Assignments)
+	s.Nil(result.Tree.Left)
+	s.Equal(0, result.Tree.Cluster)
+}
+
+func (s *BisectingSuite) TestReproducibleWithSeed() {
+	points := fourBlobs()
+
+	first, err := kmeans.BisectingKMeans(points, 6, kmeans.LargestSSE, kmeans.WithSeed(4))
+	s.Require().NoError(err)
+	second, err := kmeans.BisectingKMeans(points, 6, kmeans.LargestSSE, kmeans.WithSeed(4))
+	s.Require().NoError(err)
+
+	s.Equal(first.Centroids, second.Centroids)
+	s.Equal(first.Assignments, second.Assignments)
+}
+
+func (s *BisectingSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.BisectingKMeans(twoBlobs(), 7, kmeans.LargestSSE)
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+
+	_, err = kmeans.BisectingKMeans(twoBlobs(), 2, kmeans.SplitCriterion(9))
+	s.ErrorIs(err, kmeans.ErrUnknownSplitCriterion)
+
+	_, err = kmeans.BisectingKMeans(twoBlobs(), 2, kmeans.LargestSSE, kmeans.WithMetric(kmeans.Manhattan))
+	s.ErrorIs(err, kmeans.ErrMetricNotMeanCompatible)
+
+	duplicates := []kmeans.Point{{1, 1}, {1, 1}, {1, 1}, {2, 2}}
+	_, err = kmeans.BisectingKMeans(duplicates, 3, kmeans.LargestSSE)
+	s.ErrorIs(err, kmeans.ErrNotEnoughDistinctPoints)
+}
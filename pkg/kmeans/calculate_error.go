@@ -1,6 +1,10 @@
 package kmeans
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"runtime"
+)
 
 // CalculateSSE calculates the total within-cluster sum of squared errors (SSE).
 //
@@ -16,13 +20,47 @@ import "math"
 //
 //	SSE = Σ ||x_i - c_{a_i}||^2
 func CalculateSSE(points, centroids []Point, assignments []int) float64 {
+	return CalculateSSEWithMetric(points, centroids, assignments, Euclidean)
+}
+
+// CalculateSSEWithMetric calculates the sum of squared errors using the given metric
+// instead of the Euclidean distance.
+//
+// Formula:
+//
+//	SSE = Σ d(x_i, c_{a_i})^2
+//
+// For SquaredEuclidean the distance itself is the squared error, so the result equals CalculateSSE.
+func CalculateSSEWithMetric(points, centroids []Point, assignments []int, metric Metric) float64 {
+	return weightedSSE(points, centroids, assignments, nil, metric)
+}
+
+// CalculateWeightedSSE calculates the sum of squared errors where every point's error is
+// multiplied by its sample weight, the objective minimized by Fit with WithWeights.
+// With all weights equal to 1 it equals CalculateSSE.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - centroids: final centroids after k-means clustering
+//   - assignments: index of the centroid assigned to each point
+//   - weights: sample weight of each point; nil means unit weights
+//
+// Formula:
+//
+//	SSE = Σ w_i · ||x_i - c_{a_i}||^2
+func CalculateWeightedSSE(points, centroids []Point, assignments []int, weights []float64) float64 {
+	return weightedSSE(points, centroids, assignments, weights, Euclidean)
+}
+
+// weightedSSE sums the weighted squared errors of all points under the metric.
+func weightedSSE(points, centroids []Point, assignments []int, weights []float64, metric Metric) float64 {
 	sse := 0.0
 
 	// Iterate over all points
 	for i, point := range points {
 		centroid := centroids[assignments[i]] // Get the assigned centroid
-		dist := distance(point, centroid)     // Euclidean distance between point and centroid
-		sse += math.Pow(dist, 2)              // Add the squared distance to the total error
+		// Add the weighted squared distance to the total error
+		sse += weightOf(weights, i) * squaredError(metric, point, centroid)
 	}
 
 	return sse
@@ -46,3 +84,199 @@ func CalculateSSE(points, centroids []Point, assignments []int) float64 {
 func CalculateMSE(points, centroids []Point, assignments []int) float64 {
 	return CalculateSSE(points, centroids, assignments) / float64(len(points)) // Return Mean Squared Error
 }
+
+// CalculateMSEWithMetric calculates the Mean Squared Error using the given metric
+// instead of the Euclidean distance: MSE = CalculateSSEWithMetric / n.
+func CalculateMSEWithMetric(points, centroids []Point, assignments []int, metric Metric) float64 {
+	return CalculateSSEWithMetric(points, centroids, assignments, metric) / float64(len(points))
+}
+
+// CalculateDaviesBouldin calculates the Davies–Bouldin index of the clustering.
+// Lower values mean compact clusters that are far apart; 0 is the best possible score.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - centroids: final centroids after k-means clustering
+//   - assignments: index of the centroid assigned to each point
+//
+// Returns:
+//   - DB: float64, the average similarity of every cluster with its most similar one
+//   - err: ErrNotEnoughClusters for fewer than two non-empty clusters,
+//     ErrDegenerateClusters if two centroids coincide
+//
+// Formula:
+//
+//	S_i  = mean ||x - c_i|| over the points x of cluster i
+//	R_ij = (S_i + S_j) / ||c_i - c_j||
+//	DB   = (1 / k) Σ_i max_{j≠i} R_ij
+//
+// Clusters without points are ignored.
+func CalculateDaviesBouldin(points, centroids []Point, assignments []int) (float64, error) {
+	sizes, err := indexClusterSizes(points, centroids, assignments)
+	if err != nil {
+		return 0, err
+	}
+
+	scatter := make([]float64, len(sizes))
+	for i, point := range points {
+		scatter[assignments[i]] += distance(point, centroids[assignments[i]])
+	}
+
+	clusters := make([]int, 0, len(sizes)) // indices of the non-empty clusters
+	for j, size := range sizes {
+		if size > 0 {
+			scatter[j] /= float64(size)
+			clusters = append(clusters, j)
+		}
+	}
+
+	total := 0.0
+	for _, i := range clusters {
+		worst := 0.0
+		for _, j := range clusters {
+			if i == j {
+				continue
+			}
+
+			separation := distance(centroids[i], centroids[j])
+			if separation == 0 {
+				return 0, fmt.Errorf("%w: centroids %d and %d coincide", ErrDegenerateClusters, i, j)
+			}
+			worst = max(worst, (scatter[i]+scatter[j])/separation)
+		}
+		total += worst
+	}
+
+	return total / float64(len(clusters)), nil
+}
+
+// CalculateCalinskiHarabasz calculates the Calinski–Harabasz index (variance ratio criterion).
+// Higher values mean dense clusters that are well separated.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - centroids: final centroids after k-means clustering
+//   - assignments: index of the centroid assigned to each point
+//
+// Returns:
+//   - CH: float64, the ratio of between-cluster to within-cluster dispersion
+//   - err: ErrNotEnoughClusters for fewer than two non-empty clusters,
+//     ErrDegenerateClusters if every cluster is a singleton or there is no within-cluster dispersion
+//
+// Formula:
+//
+//	B  = Σ_j n_j ||c_j - m||^2, where m is the mean of all points
+//	W  = Σ ||x_i - c_{a_i}||^2 (the SSE)
+//	CH = (B / (k - 1)) / (W / (n - k))
+//
+// k counts the non-empty clusters only.
+func CalculateCalinskiHarabasz(points, centroids []Point, assignments []int) (float64, error) {
+	sizes, err := indexClusterSizes(points, centroids, assignments)
+	if err != nil {
+		return 0, err
+	}
+
+	k := 0
+	between := 0.0
+	overall := mean(points)
+	for j, size := range sizes {
+		if size > 0 {
+			k++
+			between += float64(size) * math.Pow(distance(centroids[j], overall), 2)
+		}
+	}
+
+	if len(points) == k {
+		return 0, fmt.Errorf("%w: every cluster is a singleton", ErrDegenerateClusters)
+	}
+
+	within := CalculateSSE(points, centroids, assignments)
+	if within == 0 {
+		return 0, fmt.Errorf("%w: no within-cluster dispersion", ErrDegenerateClusters)
+	}
+
+	return (between / float64(k-1)) / (within / float64(len(points)-k)), nil
+}
+
+// CalculateDunn calculates the Dunn index of the clustering.
+// Higher values mean clusters that are far apart compared to their size.
+// It only depends on the points, so no centroids are needed.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - assignments: index of the cluster assigned to each point
+//
+// Returns:
+//   - D: float64, the ratio of the smallest inter-cluster distance to the largest cluster diameter
+//   - err: ErrNotEnoughClusters for fewer than two non-empty clusters,
+//     ErrDegenerateClusters if every cluster is a singleton or only holds duplicate points
+//
+// Formula:
+//
+//	δ(C_i, C_j) = min ||x - y|| over x in C_i, y in C_j
+//	Δ(C_i)      = max ||x - y|| over x, y in C_i
+//	D           = min_{i≠j} δ(C_i, C_j) / max_i Δ(C_i)
+//
+// All pairwise distances are needed, O(n²), split across GOMAXPROCS goroutines that take
+// the rows in turn, so that each gets a similar share of the pairs.
+func CalculateDunn(points []Point, assignments []int) (float64, error) {
+	if _, err := indexClusterSizes(points, nil, assignments); err != nil {
+		return 0, err
+	}
+
+	// Closest point of another cluster and farthest point of the same cluster, per point
+	separation := make([]float64, len(points))
+	diameter := make([]float64, len(points))
+	parallelRows(len(points), runtime.GOMAXPROCS(0), func(i int) {
+		separation[i] = math.Inf(1)
+		for j := i + 1; j < len(points); j++ {
+			d := distance(points[i], points[j])
+			if assignments[i] == assignments[j] {
+				diameter[i] = max(diameter[i], d)
+			} else {
+				separation[i] = min(separation[i], d)
+			}
+		}
+	})
+
+	largest := 0.0
+	for _, d := range diameter {
+		largest = max(largest, d)
+	}
+	if largest == 0 {
+		return 0, fmt.Errorf("%w: every cluster is a singleton or only holds duplicate points", ErrDegenerateClusters)
+	}
+
+	smallest := math.Inf(1)
+	for _, d := range separation {
+		smallest = min(smallest, d)
+	}
+
+	return smallest / largest, nil
+}
+
+// indexClusterSizes validates the input of a validity index and returns the size of every cluster.
+// Every assigned cluster must have a centroid, unless centroids is nil.
+func indexClusterSizes(points, centroids []Point, assignments []int) ([]int, error) {
+	k, err := validateAssignments(points, assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	if centroids != nil && k > len(centroids) {
+		return nil, fmt.Errorf("%w: cluster %d has no centroid", ErrInvalidAssignment, k-1)
+	}
+
+	sizes := clusterSizes(assignments, k)
+	nonEmpty := 0
+	for _, size := range sizes {
+		if size > 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty < 2 {
+		return nil, ErrNotEnoughClusters
+	}
+
+	return sizes, nil
+}
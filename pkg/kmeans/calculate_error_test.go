@@ -1,6 +1,7 @@
 package kmeans_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
@@ -74,3 +75,168 @@ func (s *CalculateErrorSuite) TestCalculatingMSE_3DTwoClusters() {
 	actual := kmeans.CalculateMSE(points, centroids, assignments)
 	s.InDelta(expected, actual, 1e-9)
 }
+
+type ValidityIndicesSuite struct {
+	suite.Suite
+}
+
+func TestValidityIndicesSuite(t *testing.T) {
+	suite.Run(t, new(ValidityIndicesSuite))
+}
+
+// referenceClusters returns three 2D clusters of different spreads with their mean centroids.
+func referenceClusters() ([]kmeans.Point, []kmeans.Point, []int) {
+	points := []kmeans.Point{
+		{0, 0}, {2, 0}, {0, 2}, {2, 2},
+		{10, 0}, {11, 0}, {10, 1}, {11, 1},
+		{0, 10}, {0, 12}, {0, 14},
+	}
+	centroids := []kmeans.Point{{1, 1}, {10.5, 0.5}, {0, 12}}
+	assignments := []int{0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2}
+
+	return points, centroids, assignments
+}
+
+func (s *ValidityIndicesSuite) TestDaviesBouldinReference() {
+	points, centroids, assignments := referenceClusters()
+
+	actual, err := kmeans.CalculateDaviesBouldin(points, centroids, assignments)
+	s.Require().NoError(err)
+
+	// S = (√2, √2/2, 4/3); every cluster is most similar to the first one
+	scatter := []float64{math.Sqrt2, math.Sqrt2 / 2, 4.0 / 3}
+	r01 := (scatter[0] + scatter[1]) / math.Hypot(9.5, 0.5)
+	r02 := (scatter[0] + scatter[2]) / math.Hypot(1, 11)
+	s.InDelta((max(r01, r02)+r01+r02)/3, actual, 1e-12)
+	s.InDelta(0.24016355328444153, actual, 1e-12)
+}
+
+func (s *ValidityIndicesSuite) TestCalinskiHarabaszReference() {
+	points, centroids, assignments := referenceClusters()
+
+	actual, err := kmeans.CalculateCalinskiHarabasz(points, centroids, assignments)
+	s.Require().NoError(err)
+
+	// B = 4·|c_0 - m|² + 4·|c_1 - m|² + 3·|c_2 - m|² with m = (46/11, 42/11), W = 8 + 2 + 8
+	s.InDelta(117.6161616161616, actual, 1e-9)
+}
+
+func (s *ValidityIndicesSuite) TestDunnReference() {
+	points, _, assignments := referenceClusters()
+
+	actual, err := kmeans.CalculateDunn(points, assignments)
+	s.Require().NoError(err)
+
+	// Closest pair (2, 0)-(10, 0) is 8 apart, the widest cluster (0, 10)-(0, 14) spans 4
+	s.InDelta(2.0, actual, 1e-12)
+}
+
+func (s *ValidityIndicesSuite) TestDunnOfLargeInput() {
+	// Enough points for the pass to be split across goroutines
+	points := randomPoints(600, 2, 5)
+	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = i % 3
+	}
+
+	actual, err := kmeans.CalculateDunn(points, assignments)
+	s.Require().NoError(err)
+
+	separation, diameter := math.Inf(1), 0.0
+	for i := range points {
+		for j := range i {
+			d := kmeans.Euclidean.Distance(points[i], points[j])
+			if assignments[i] == assignments[j] {
+				diameter = max(diameter, d)
+			} else {
+				separation = min(separation, d)
+			}
+		}
+	}
+	s.InDelta(separation/diameter, actual, 1e-12)
+}
+
+func (s *ValidityIndicesSuite) TestWellSeparatedScoresBetter() {
+	points, centroids, assignments := referenceClusters()
+	mixed := []int{0, 1, 2, 0, 1, 2, 0, 1, 2, 0, 1}
+	mixedCentroids := []kmeans.Point{meanOf(points, mixed, 0), meanOf(points, mixed, 1), meanOf(points, mixed, 2)}
+
+	good, err := kmeans.CalculateDaviesBouldin(points, centroids, assignments)
+	s.Require().NoError(err)
+	bad, err := kmeans.CalculateDaviesBouldin(points, mixedCentroids, mixed)
+	s.Require().NoError(err)
+	s.Less(good, bad)
+
+	good, err = kmeans.CalculateCalinskiHarabasz(points, centroids, assignments)
+	s.Require().NoError(err)
+	bad, err = kmeans.CalculateCalinskiHarabasz(points, mixedCentroids, mixed)
+	s.Require().NoError(err)
+	s.Greater(good, bad)
+
+	good, err = kmeans.CalculateDunn(points, assignments)
+	s.Require().NoError(err)
+	bad, err = kmeans.CalculateDunn(points, mixed)
+	s.Require().NoError(err)
+	s.Greater(good, bad)
+}
+
+// meanOf returns the mean of the points assigned to the cluster.
+func meanOf(points []kmeans.Point, assignments []int, cluster int) kmeans.Point {
+	mean, count := make(kmeans.Point, len(points[0])), 0.0
+	for i, p := range points {
+		if assignments[i] == cluster {
+			count++
+			for d := range p {
+				mean[d] += p[d]
+			}
+		}
+	}
+	for d := range mean {
+		mean[d] /= count
+	}
+
+	return mean
+}
+
+func (s *ValidityIndicesSuite) TestSingleClusterReturnsError() {
+	points := []kmeans.Point{{0, 0}, {1, 1}, {2, 2}}
+	centroids := []kmeans.Point{{1, 1}}
+	assignments := []int{0, 0, 0}
+
+	_, err := kmeans.CalculateDaviesBouldin(points, centroids, assignments)
+	s.ErrorIs(err, kmeans.ErrNotEnoughClusters)
+	_, err = kmeans.CalculateCalinskiHarabasz(points, centroids, assignments)
+	s.ErrorIs(err, kmeans.ErrNotEnoughClusters)
+	_, err = kmeans.CalculateDunn(points, assignments)
+	s.ErrorIs(err, kmeans.ErrNotEnoughClusters)
+}
+
+func (s *ValidityIndicesSuite) TestSingletonClustersReturnError() {
+	points := []kmeans.Point{{0, 0}, {1, 1}, {5, 5}}
+	assignments := []int{0, 1, 2}
+
+	_, err := kmeans.CalculateCalinskiHarabasz(points, points, assignments)
+	s.ErrorIs(err, kmeans.ErrDegenerateClusters)
+	_, err = kmeans.CalculateDunn(points, assignments)
+	s.ErrorIs(err, kmeans.ErrDegenerateClusters)
+}
+
+func (s *ValidityIndicesSuite) TestCoincidingCentroidsReturnError() {
+	points := []kmeans.Point{{0, 0}, {2, 2}, {0, 2}, {2, 0}}
+	centroids := []kmeans.Point{{1, 1}, {1, 1}}
+	assignments := []int{0, 0, 1, 1}
+
+	_, err := kmeans.CalculateDaviesBouldin(points, centroids, assignments)
+	s.ErrorIs(err, kmeans.ErrDegenerateClusters)
+}
+
+func (s *ValidityIndicesSuite) TestInvalidInputReturnsError() {
+	points, centroids, assignments := referenceClusters()
+
+	_, err := kmeans.CalculateDaviesBouldin(points, centroids, assignments[1:])
+	s.ErrorIs(err, kmeans.ErrAssignmentsMismatch)
+	_, err = kmeans.CalculateCalinskiHarabasz(points, centroids[:2], assignments)
+	s.ErrorIs(err, kmeans.ErrInvalidAssignment)
+	_, err = kmeans.CalculateDunn(nil, nil)
+	s.ErrorIs(err, kmeans.ErrNoPoints)
+}
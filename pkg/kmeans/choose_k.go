@@ -0,0 +1,323 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// SelectionMethod is the criterion ChooseK uses to pick the number of clusters.
+type SelectionMethod int
+
+const (
+	// Elbow picks the knee of the SSE curve with the kneedle algorithm (Satopää et al.).
+	Elbow SelectionMethod = iota
+	// MaxSilhouette picks the k with the highest mean silhouette. It needs kMin >= 2.
+	MaxSilhouette
+	// GapStatistic picks the smallest k with Gap(k) >= Gap(k+1) - s_{k+1} (Tibshirani et al.),
+	// comparing the SSE with that of uniform reference datasets, see WithGapReferences.
+	GapStatistic
+)
+
+// String returns a human-readable name of the selection method.
+func (m SelectionMethod) String() string {
+	switch m {
+	case Elbow:
+		return "elbow"
+	case MaxSilhouette:
+		return "silhouette"
+	case GapStatistic:
+		return "gap statistic"
+	default:
+		return fmt.Sprintf("SelectionMethod(%d)", int(m))
+	}
+}
+
+// KSelection describes the sweep over k performed by ChooseK.
+type KSelection struct {
+	K       int       // chosen number of clusters
+	Ks      []int     // evaluated numbers of clusters, kMin to kMax
+	Scores  []float64 // score curve: SSE for Elbow, mean silhouette for MaxSilhouette, Gap(k) for GapStatistic
+	StdErr  []float64 // s_k of the gap statistic; nil for the other methods
+	SSE     []float64 // SSE of the clustering of every k
+	Results []*Result // clustering of every k
+}
+
+// ChooseK clusters the points for every k from kMin to kMax and picks the best k with the given method.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - kMin, kMax: range of k to evaluate, 1 <= kMin <= kMax <= n
+//   - method: Elbow (at least three values of k), MaxSilhouette (kMin >= 2) or GapStatistic
+//   - opts: options of every Fit run; WithK is ignored. WithParallelSweep evaluates
+//     the values of k concurrently and WithGapReferences sets the reference datasets of GapStatistic.
+//
+// Returns:
+//   - selection: chosen k with the score curve and the clustering of every k
+//   - err: one of the Err* validation errors, or the first error of a Fit run; GapStatistic returns
+//     ErrNotEnoughDistinctPoints when a clustering has zero SSE, where log W_k is undefined
+//
+// Every run gets its own seed drawn up front from the source of the sweep,
+// so WithSeed makes the result reproducible whether or not the sweep runs in parallel.
+func ChooseK(points []Point, kMin, kMax int, method SelectionMethod, opts ...Option) (*KSelection, error) {
+	if err := validateKRange(kMin, kMax, method); err != nil {
+		return nil, err
+	}
+
+	cfg := newConfig(append(slices.Clone(opts), WithK(kMax)))
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateSelection(method); err != nil {
+		return nil, err
+	}
+
+	sweep := newKSweep(points, kMin, kMax, method, opts, &cfg)
+	errs := make([]error, len(sweep.selection.Ks))
+	if cfg.parallelSweep {
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = sweep.evaluate(i)
+			}()
+		}
+		wg.Wait()
+	} else {
+		for i := range errs {
+			errs[i] = sweep.evaluate(i)
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	selection := sweep.selection
+	switch method {
+	case MaxSilhouette:
+		selection.K = selection.Ks[slices.Index(selection.Scores, slices.Max(selection.Scores))]
+	case GapStatistic:
+		selection.K = selection.Ks[gapChoice(selection.Scores, selection.StdErr)]
+	default:
+		selection.K = selection.Ks[kneedle(selection.Scores)]
+	}
+
+	return selection, nil
+}
+
+// validateKRange checks the range of k against the requirements of the method.
+func validateKRange(kMin, kMax int, method SelectionMethod) error {
+	if kMin < 1 || kMax < kMin {
+		return fmt.Errorf("%w: [%d, %d]", ErrInvalidKRange, kMin, kMax)
+	}
+
+	switch method {
+	case Elbow:
+		if kMax-kMin < 2 {
+			return fmt.Errorf("%w: %s needs at least three values of k", ErrInvalidKRange, method)
+		}
+	case MaxSilhouette:
+		if kMin < 2 {
+			return fmt.Errorf("%w: %s needs kMin >= 2", ErrInvalidKRange, method)
+		}
+	case GapStatistic:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownSelectionMethod, method)
+	}
+
+	return nil
+}
+
+// validateSelection checks the options specific to the selection method.
+func (c *config) validateSelection(method SelectionMethod) error {
+	if method != Elbow {
+		if err := c.rejectWeights(method.String()); err != nil {
+			return err
+		}
+	}
+
+	if method == GapStatistic && c.gapReferences <= 0 {
+		return ErrInvalidNumberOfReferences
+	}
+
+	return nil
+}
+
+// kSweep holds the shared state of a ChooseK sweep. Every value of k only writes its own entries.
+type kSweep struct {
+	points     []Point
+	opts       []Option
+	method     SelectionMethod
+	metric     Metric
+	seeds      []int64   // seed of the clustering of every k
+	references [][]Point // uniform reference datasets of the gap statistic
+	refSeeds   [][]int64 // seeds of the reference clusterings of every k
+	selection  *KSelection
+}
+
+// newKSweep draws every seed and reference dataset of the sweep from the source of cfg.
+func newKSweep(points []Point, kMin, kMax int, method SelectionMethod, opts []Option, cfg *config) *kSweep {
+	n := kMax - kMin + 1
+	sweep := &kSweep{
+		points: points,
+		opts:   opts,
+		method: method,
+		metric: cfg.metric,
+		seeds:  make([]int64, n),
+		selection: &KSelection{
+			Ks:      make([]int, n),
+			Scores:  make([]float64, n),
+			SSE:     make([]float64, n),
+			Results: make([]*Result, n),
+		},
+	}
+
+	for i := range n {
+		sweep.selection.Ks[i] = kMin + i
+		sweep.seeds[i] = cfg.rng.Int63()
+	}
+
+	if method == GapStatistic {
+		sweep.selection.StdErr = make([]float64, n)
+		lower, upper := findMin(points), findMax(points)
+		sweep.references = make([][]Point, cfg.gapReferences)
+		for b := range sweep.references {
+			sweep.references[b] = uniformReference(len(points), lower, upper, cfg.rng)
+		}
+
+		sweep.refSeeds = make([][]int64, n)
+		for i := range sweep.refSeeds {
+			sweep.refSeeds[i] = make([]int64, cfg.gapReferences)
+			for b := range sweep.refSeeds[i] {
+				sweep.refSeeds[i][b] = cfg.rng.Int63()
+			}
+		}
+	}
+
+	return sweep
+}
+
+// evaluate clusters the points with the i-th value of k and computes its score.
+func (s *kSweep) evaluate(i int) error {
+	k := s.selection.Ks[i]
+	result, err := Fit(s.points, s.fitOptions(k, s.seeds[i])...)
+	if err != nil {
+		return fmt.Errorf("k=%d: %w", k, err)
+	}
+	s.selection.Results[i], s.selection.SSE[i] = result, result.SSE
+
+	switch s.method {
+	case MaxSilhouette:
+		silhouette, err := Silhouette(s.points, result.Assignments, s.metric)
+		if err != nil {
+			return fmt.Errorf("k=%d: %w", k, err)
+		}
+		s.selection.Scores[i] = silhouette.Mean
+	case GapStatistic:
+		return s.gap(i)
+	default:
+		s.selection.Scores[i] = result.SSE
+	}
+
+	return nil
+}
+
+// gap computes Gap(k) and s_k for the i-th value of k.
+//
+// Formula:
+//
+//	Gap(k) = (1 / B) Σ_b log W*_kb - log W_k
+//	s_k    = sd(log W*_kb) · sqrt(1 + 1 / B)
+//
+// W_k is the SSE of the points and W*_kb the SSE of the b-th reference dataset, both with k clusters.
+// The logarithm is undefined for a zero SSE, i.e. when there are no more distinct points than
+// clusters, so ErrNotEnoughDistinctPoints is returned instead of an infinite score.
+func (s *kSweep) gap(i int) error {
+	k := s.selection.Ks[i]
+	if s.selection.SSE[i] == 0 {
+		return fmt.Errorf("k=%d: %w: zero SSE", k, ErrNotEnoughDistinctPoints)
+	}
+
+	logs := make([]float64, len(s.references))
+	for b, reference := range s.references {
+		result, err := Fit(reference, s.fitOptions(k, s.refSeeds[i][b])...)
+		if err != nil {
+			return fmt.Errorf("k=%d, reference %d: %w", k, b, err)
+		}
+		if result.SSE == 0 {
+			return fmt.Errorf("k=%d, reference %d: %w: zero SSE", k, b, ErrNotEnoughDistinctPoints)
+		}
+		logs[b] = math.Log(result.SSE)
+	}
+
+	references := float64(len(logs))
+	mean := sum(logs) / references
+	variance := 0.0
+	for _, l := range logs {
+		variance += (l - mean) * (l - mean)
+	}
+
+	s.selection.Scores[i] = mean - math.Log(s.selection.SSE[i])
+	s.selection.StdErr[i] = math.Sqrt(variance/references) * math.Sqrt(1+1/references)
+
+	return nil
+}
+
+// fitOptions returns the options of the sweep for a single run with k clusters and the given seed.
+func (s *kSweep) fitOptions(k int, seed int64) []Option {
+	return append(slices.Clone(s.opts), WithK(k), WithSeed(seed))
+}
+
+// uniformReference draws n points uniformly from the bounding box [lower, upper].
+func uniformReference(n int, lower, upper Point, rng *rand.Rand) []Point {
+	reference := make([]Point, n)
+	for i := range reference {
+		p := make(Point, len(lower))
+		for d := range p {
+			p[d] = lower[d] + rng.Float64()*(upper[d]-lower[d])
+		}
+		reference[i] = p
+	}
+
+	return reference
+}
+
+// kneedle returns the index of the knee of a decreasing convex curve: after scaling both axes
+// to [0, 1] and flipping the curve, the point farthest above the diagonal.
+// A flat curve has no knee, so the first index is returned.
+func kneedle(curve []float64) int {
+	lowest, highest := slices.Min(curve), slices.Max(curve)
+	if lowest == highest {
+		return 0
+	}
+
+	last := float64(len(curve) - 1)
+	knee, bestDifference := 0, math.Inf(-1)
+	for i, value := range curve {
+		x := float64(i) / last
+		y := (highest - value) / (highest - lowest)
+		if y-x > bestDifference {
+			knee, bestDifference = i, y-x
+		}
+	}
+
+	return knee
+}
+
+// gapChoice returns the smallest index i with Gap(i) >= Gap(i+1) - s_{i+1}, or the last one.
+func gapChoice(gaps, stdErr []float64) int {
+	for i := 0; i+1 < len(gaps); i++ {
+		if gaps[i] >= gaps[i+1]-stdErr[i+1] {
+			return i
+		}
+	}
+
+	return len(gaps) - 1
+}
@@ -0,0 +1,117 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChooseKSuite struct {
+	suite.Suite
+}
+
+func TestChooseKSuite(t *testing.T) {
+	suite.Run(t, new(ChooseKSuite))
+}
+
+// threeBlobs returns three well separated 2D Gaussian blobs.
+func threeBlobs() []kmeans.Point {
+	return gaussianBlobs([]kmeans.Point{{0, 0}, {10, 0}, {5, 9}}, 60, 0.7, 3)
+}
+
+func (s *ChooseKSuite) TestMethodsFindThreeBlobs() {
+	points := threeBlobs()
+
+	for _, tc := range []struct {
+		method kmeans.SelectionMethod
+		kMin   int
+	}{
+		{kmeans.Elbow, 1},
+		{kmeans.MaxSilhouette, 2},
+		{kmeans.GapStatistic, 1},
+	} {
+		selection, err := kmeans.ChooseK(points, tc.kMin, 6, tc.method, kmeans.WithSeed(7))
+		s.Require().NoError(err, tc.method.String())
+
+		s.Equal(3, selection.K, tc.method.String())
+		s.Len(selection.Ks, 7-tc.kMin)
+		s.Equal(tc.kMin, selection.Ks[0])
+		s.Len(selection.Scores, len(selection.Ks))
+		s.Len(selection.Results, len(selection.Ks))
+		for i, result := range selection.Results {
+			s.Len(result.Centroids, selection.Ks[i])
+			s.InDelta(result.SSE, selection.SSE[i], 1e-9)
+		}
+	}
+}
+
+func (s *ChooseKSuite) TestElbowScoresAreSSE() {
+	selection, err := kmeans.ChooseK(threeBlobs(), 1, 5, kmeans.Elbow, kmeans.WithSeed(1))
+	s.Require().NoError(err)
+
+	s.Equal(selection.SSE, selection.Scores)
+	s.Nil(selection.StdErr)
+}
+
+func (s *ChooseKSuite) TestGapStatisticReportsStandardErrors() {
+	selection, err := kmeans.ChooseK(threeBlobs(), 1, 4, kmeans.GapStatistic,
+		kmeans.WithSeed(2), kmeans.WithGapReferences(5))
+	s.Require().NoError(err)
+
+	s.Len(selection.StdErr, 4)
+	for _, stdErr := range selection.StdErr {
+		s.GreaterOrEqual(stdErr, 0.0)
+	}
+	s.Greater(selection.Scores[2], selection.Scores[0])
+}
+
+func (s *ChooseKSuite) TestParallelSweepMatchesSequential() {
+	points := threeBlobs()
+	opts := []kmeans.Option{kmeans.WithSeed(5), kmeans.WithGapReferences(3)}
+
+	sequential, err := kmeans.ChooseK(points, 1, 5, kmeans.GapStatistic, opts...)
+	s.Require().NoError(err)
+	parallel, err := kmeans.ChooseK(points, 1, 5, kmeans.GapStatistic, append(opts, kmeans.WithParallelSweep())...)
+	s.Require().NoError(err)
+
+	s.Equal(sequential.K, parallel.K)
+	s.Equal(sequential.Scores, parallel.Scores)
+	s.Equal(sequential.StdErr, parallel.StdErr)
+	s.Equal(sequential.SSE, parallel.SSE)
+}
+
+func (s *ChooseKSuite) TestInvalidInputReturnsError() {
+	points := threeBlobs()
+
+	_, err := kmeans.ChooseK(points, 0, 4, kmeans.GapStatistic)
+	s.ErrorIs(err, kmeans.ErrInvalidKRange)
+
+	_, err = kmeans.ChooseK(points, 4, 3, kmeans.GapStatistic)
+	s.ErrorIs(err, kmeans.ErrInvalidKRange)
+
+	_, err = kmeans.ChooseK(points, 2, 3, kmeans.Elbow)
+	s.ErrorIs(err, kmeans.ErrInvalidKRange)
+
+	_, err = kmeans.ChooseK(points, 1, 3, kmeans.MaxSilhouette)
+	s.ErrorIs(err, kmeans.ErrInvalidKRange)
+
+	_, err = kmeans.ChooseK(points, 1, 3, kmeans.SelectionMethod(42))
+	s.ErrorIs(err, kmeans.ErrUnknownSelectionMethod)
+
+	_, err = kmeans.ChooseK(points[:3], 1, 4, kmeans.GapStatistic)
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+
+	_, err = kmeans.ChooseK(points, 1, 3, kmeans.GapStatistic, kmeans.WithGapReferences(0))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfReferences)
+}
+
+func (s *ChooseKSuite) TestGapStatisticRejectsZeroSSE() {
+	duplicates := []kmeans.Point{{0, 0}, {0, 0}, {0, 0}, {5, 5}, {5, 5}, {5, 5}}
+	_, err := kmeans.ChooseK(duplicates, 1, 3, kmeans.GapStatistic, kmeans.WithSeed(1))
+	s.ErrorIs(err, kmeans.ErrNotEnoughDistinctPoints)
+
+	// Every point is its own cluster at k = n, whatever the data
+	_, err = kmeans.ChooseK(twoBlobs(), 1, 6, kmeans.GapStatistic, kmeans.WithSeed(1))
+	s.ErrorIs(err, kmeans.ErrNotEnoughDistinctPoints)
+}
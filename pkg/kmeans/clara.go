@@ -0,0 +1,145 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+)
+
+const (
+	// claraBaseSampleSize is the part of the default CLARA sample size that does not grow with k.
+	claraBaseSampleSize = 40
+	// claransMinNeighbors is the smallest default number of neighbors CLARANS tries.
+	claransMinNeighbors = 250
+	// claransNeighborFraction is the default share of the k(n-k) neighbors CLARANS tries.
+	claransNeighborFraction = 0.0125
+)
+
+// subsetDissimilarities restricts the dissimilarities to the objects with the given indices.
+type subsetDissimilarities struct {
+	d       dissimilarities
+	indices []int
+}
+
+func (s subsetDissimilarities) len() int { return len(s.indices) }
+
+func (s subsetDissimilarities) between(i, j int) float64 {
+	return s.d.between(s.indices[i], s.indices[j])
+}
+
+// clara runs PAM on cfg.samples random samples and keeps the medoids with the lowest deviation
+// over all objects. Every sample after the first contains the best medoids found so far.
+//
+// Kaufman, L. and Rousseeuw, P. J. (1990). Finding Groups in Data, chapter 3.
+func (s *medoidState) clara(cfg *config) {
+	n, k := s.d.len(), cfg.k
+	size := cfg.sampleSize
+	if size == 0 {
+		size = claraBaseSampleSize + 2*k
+	}
+
+	samples := cfg.samples
+	if size >= n {
+		size, samples = n, 1 // every sample would hold all objects
+	}
+
+	var best []int
+	bestDeviation, bestConverged, swaps := math.Inf(1), false, 0
+	for range samples {
+		indices := sampleIndices(n, size, best, cfg.rng)
+		sample := &medoidState{d: subsetDissimilarities{d: s.d, indices: indices}, workers: s.workers}
+		sample.build(k)
+		sample.pamSwap(cfg.maxIterations)
+		swaps += sample.swaps
+
+		s.medoids = make([]int, k)
+		for m, medoid := range sample.medoids {
+			s.medoids[m] = indices[medoid]
+		}
+		s.assign()
+
+		if deviation := s.deviation(); deviation < bestDeviation {
+			best, bestDeviation, bestConverged = s.medoids, deviation, sample.converged
+		}
+	}
+
+	if !slices.Equal(s.medoids, best) {
+		s.medoids = best
+		s.assign()
+	}
+	s.swaps, s.converged = swaps, bestConverged
+}
+
+// sampleIndices draws size distinct object indices out of n, starting with the given ones.
+func sampleIndices(n, size int, include []int, rng *rand.Rand) []int {
+	if size == n {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	indices := slices.Clone(include)
+	chosen := make(map[int]bool, size)
+	for _, i := range indices {
+		chosen[i] = true
+	}
+
+	for len(indices) < size {
+		if i := rng.Intn(n); !chosen[i] {
+			chosen[i] = true
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// clarans searches cfg.localSearches times from random medoids and keeps the best local minimum.
+// A search performs the first improving swap among random medoid/non-medoid pairs and ends once
+// cfg.maxNeighbors consecutive pairs do not improve, or after cfg.maxIterations swaps.
+//
+// Ng, R. T. and Han, J. (2002). CLARANS: a method for clustering objects for spatial data mining.
+// IEEE Transactions on Knowledge and Data Engineering, 14(5), 1003–1016.
+func (s *medoidState) clarans(cfg *config) {
+	n, k := s.d.len(), cfg.k
+	neighbors := cfg.maxNeighbors
+	if neighbors == 0 {
+		neighbors = max(claransMinNeighbors, int(claransNeighborFraction*float64(k*(n-k))))
+	}
+
+	var best []int
+	bestDeviation, bestConverged, swaps := math.Inf(1), false, 0
+	for range cfg.localSearches {
+		s.random(k, cfg.rng)
+		s.swaps, s.converged = 0, true
+		for failed := 0; failed < neighbors && k < n; failed++ {
+			m, h := cfg.rng.Intn(k), cfg.rng.Intn(n)
+			for s.isMedoid(h) {
+				h = cfg.rng.Intn(n)
+			}
+			if s.swapDelta(m, h) >= 0 {
+				continue
+			}
+
+			if s.swaps == cfg.maxIterations {
+				s.converged = false
+				break
+			}
+			s.swap(m, h)
+			failed = -1 // count the failures after the last swap
+		}
+		swaps += s.swaps
+
+		if deviation := s.deviation(); deviation < bestDeviation {
+			best, bestDeviation, bestConverged = slices.Clone(s.medoids), deviation, s.converged
+		}
+	}
+
+	if !slices.Equal(s.medoids, best) {
+		s.medoids = best
+		s.assign()
+	}
+	s.swaps, s.converged = swaps, bestConverged
+}
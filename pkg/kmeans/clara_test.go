@@ -0,0 +1,113 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClaraSuite struct {
+	suite.Suite
+}
+
+func TestClaraSuite(t *testing.T) {
+	suite.Run(t, new(ClaraSuite))
+}
+
+func (s *ClaraSuite) TestCloseToFasterPAM() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {20, 3}, {5, 25}, {30, 30}}, 500, 2, 14)
+
+	reference, err := kmeans.KMedoids(points, 4, kmeans.FasterPAM, kmeans.WithSeed(1))
+	s.Require().NoError(err)
+
+	for _, method := range []kmeans.MedoidsMethod{kmeans.CLARA, kmeans.CLARANS} {
+		result, err := kmeans.KMedoids(points, 4, method, kmeans.WithSeed(2))
+		s.Require().NoError(err, method.String())
+
+		s.Len(result.Medoids, 4)
+		s.Len(result.Assignments, len(points))
+		s.Equal([]int{500, 500, 500, 500}, sortedSizes(result.ClusterSizes), method.String())
+		s.InEpsilon(reference.Deviation, result.Deviation, 0.05, method.String())
+		s.True(result.Converged)
+	}
+}
+
+func (s *ClaraSuite) TestWholeSampleIsPAM() {
+	points := fourBlobs()
+
+	pam, err := kmeans.KMedoids(points, 4, kmeans.PAM)
+	s.Require().NoError(err)
+	clara, err := kmeans.KMedoids(points, 4, kmeans.CLARA, kmeans.WithSampleSize(len(points)+1))
+	s.Require().NoError(err)
+
+	s.Equal(pam.Medoids, clara.Medoids)
+	s.Equal(pam.Assignments, clara.Assignments)
+	s.Equal(pam.Swaps, clara.Swaps)
+}
+
+func (s *ClaraSuite) TestMatrixMatchesPoints() {
+	points := fourBlobs()
+	matrix := dissimilarityMatrix(points, kmeans.Manhattan)
+
+	for _, method := range []kmeans.MedoidsMethod{kmeans.CLARA, kmeans.CLARANS} {
+		fromPoints, err := kmeans.KMedoids(points, 4, method, kmeans.WithMetric(kmeans.Manhattan), kmeans.WithSeed(3))
+		s.Require().NoError(err)
+		fromMatrix, err := kmeans.KMedoidsMatrix(matrix, 4, method, kmeans.WithSeed(3))
+		s.Require().NoError(err)
+
+		s.Equal(fromPoints.Medoids, fromMatrix.Medoids, method.String())
+		s.InDelta(fromPoints.Deviation, fromMatrix.Deviation, 1e-9)
+	}
+}
+
+func (s *ClaraSuite) TestReproducibleWithSeed() {
+	points := fourBlobs()
+
+	for _, method := range []kmeans.MedoidsMethod{kmeans.CLARA, kmeans.CLARANS} {
+		first, err := kmeans.KMedoids(points, 5, method, kmeans.WithSeed(4), kmeans.WithWorkers(1))
+		s.Require().NoError(err)
+		second, err := kmeans.KMedoids(points, 5, method, kmeans.WithSeed(4), kmeans.WithWorkers(4))
+		s.Require().NoError(err)
+
+		s.Equal(first.Medoids, second.Medoids, method.String())
+		s.Equal(first.Assignments, second.Assignments)
+		s.Equal(first.Swaps, second.Swaps)
+	}
+}
+
+func (s *ClaraSuite) TestEveryObjectIsAMedoid() {
+	for _, method := range []kmeans.MedoidsMethod{kmeans.CLARA, kmeans.CLARANS} {
+		result, err := kmeans.KMedoids(twoBlobs(), 6, method, kmeans.WithSeed(5))
+		s.Require().NoError(err)
+
+		s.ElementsMatch([]int{0, 1, 2, 3, 4, 5}, result.Medoids)
+		s.InDelta(0.0, result.Deviation, 1e-12)
+	}
+}
+
+func (s *ClaraSuite) TestIterationCap() {
+	result, err := kmeans.KMedoids(fourBlobs(), 4, kmeans.CLARANS,
+		kmeans.WithSeed(6), kmeans.WithMaxIterations(1), kmeans.WithLocalSearches(1))
+	s.Require().NoError(err)
+
+	s.Equal(1, result.Swaps)
+	s.False(result.Converged)
+}
+
+func (s *ClaraSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.KMedoids(fourBlobs(), 4, kmeans.CLARA, kmeans.WithSamples(0))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfSamples)
+
+	_, err = kmeans.KMedoids(fourBlobs(), 4, kmeans.CLARA, kmeans.WithSampleSize(3))
+	s.ErrorIs(err, kmeans.ErrSampleTooSmall)
+
+	_, err = kmeans.KMedoids(fourBlobs(), 4, kmeans.CLARANS, kmeans.WithLocalSearches(-1))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfSearches)
+
+	_, err = kmeans.KMedoids(fourBlobs(), 4, kmeans.CLARANS, kmeans.WithMaxNeighbors(-5))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfNeighbors)
+
+	_, err = kmeans.KMedoidsMatrix([][]float64{{0, 1}, {1, 0}}, 1, kmeans.CLARA, kmeans.WithSamples(-1))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfSamples)
+}
@@ -0,0 +1,347 @@
+package kmeans
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+)
+
+// SizeConstrainedKMeans performs k-means clustering where every cluster must receive between
+// minSize and maxSize points (Bradley, Bennett and Demiriz, "Constrained K-Means Clustering").
+// It is equivalent to SizeConstrainedKMeansContext with a background context.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - minSize: the smallest number of points of a cluster; k·minSize must not exceed n
+//   - maxSize: the largest number of points of a cluster; k·maxSize must be at least n
+//   - opts: run configuration as for Fit; WithK is required
+//
+// Returns:
+//   - result: centroids, assignments and statistics of the run, as reported by Fit
+//   - err: one of the Err* validation errors, or ErrInvalidSizeBounds if the bounds cannot be met
+func SizeConstrainedKMeans(points []Point, minSize, maxSize int, opts ...Option) (*Result, error) {
+	return SizeConstrainedKMeansContext(context.Background(), points, minSize, maxSize, opts...)
+}
+
+// SizeConstrainedKMeansContext performs size-constrained k-means clustering like SizeConstrainedKMeans,
+// but stops as soon as ctx is done, returning a partial Result as FitContext does.
+//
+// The assignment step is solved exactly as a minimum-cost flow from the points to the clusters:
+// it gives the assignment with the smallest SSE among those that respect the size bounds, so the
+// SSE never increases between iterations. Points are added one at a time along the cheapest chain
+// of moves between clusters (successive shortest paths), found with Dijkstra over the k clusters,
+// so an assignment step takes O(n·k²·log n) time on top of the n·k distances.
+// The update step, stop criteria, initializers, restarts and sample weights are those of Fit;
+// the bounds count points, not weights. An empty cluster, only possible with minSize 0, keeps its
+// centroid unless FailOnEmptyCluster is set, since reseeding could break the bounds.
+// Only Lloyd iterations are supported; other algorithms return ErrAlgorithmNotSupported.
+//
+// Arguments:
+//   - ctx: controls cancellation and deadline of the run
+//   - points: dataset of n-dimensional points
+//   - minSize: the smallest number of points of a cluster
+//   - maxSize: the largest number of points of a cluster
+//   - opts: run configuration as for Fit; WithK is required
+//
+// Returns:
+//   - result: centroids, assignments and statistics of the run; nil only for invalid input
+//   - err: one of the Err* validation errors, or ctx.Err() if the run was interrupted
+func SizeConstrainedKMeansContext(
+	ctx context.Context, points []Point, minSize, maxSize int, opts ...Option,
+) (*Result, error) {
+	return sizeConstrained(ctx, points, opts, func(int, int) (int, int) { return minSize, maxSize })
+}
+
+// BalancedKMeans performs k-means clustering with clusters as equal in size as possible:
+// every cluster gets ⌊n/k⌋ or ⌈n/k⌉ points. See SizeConstrainedKMeansContext for the details.
+// It is equivalent to BalancedKMeansContext with a background context.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - opts: run configuration as for Fit; WithK is required
+//
+// Returns:
+//   - result: centroids, assignments and statistics of the run, as reported by Fit
+//   - err: one of the Err* validation errors if the input or options are invalid
+func BalancedKMeans(points []Point, opts ...Option) (*Result, error) {
+	return BalancedKMeansContext(context.Background(), points, opts...)
+}
+
+// BalancedKMeansContext performs balanced k-means clustering like BalancedKMeans, but stops as soon
+// as ctx is done, returning a partial Result as FitContext does.
+//
+// Arguments:
+//   - ctx: controls cancellation and deadline of the run
+//   - points: dataset of n-dimensional points
+//   - opts: run configuration as for Fit; WithK is required
+//
+// Returns:
+//   - result: centroids, assignments and statistics of the run; nil only for invalid input
+//   - err: one of the Err* validation errors, or ctx.Err() if the run was interrupted
+func BalancedKMeansContext(ctx context.Context, points []Point, opts ...Option) (*Result, error) {
+	return sizeConstrained(ctx, points, opts, func(n, k int) (int, int) {
+		return n / k, (n + k - 1) / k
+	})
+}
+
+// sizeConstrained validates the options and the size bounds computed from n and k, then runs Fit
+// with the constrained assignment step.
+func sizeConstrained(
+	ctx context.Context, points []Point, opts []Option, bounds func(n, k int) (int, int),
+) (*Result, error) {
+	cfg := newConfig(opts)
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if !cfg.metric.MeanCentroid() {
+		return nil, fmt.Errorf("%w: %s", ErrMetricNotMeanCompatible, metricName(cfg.metric))
+	}
+
+	if cfg.algorithm != Lloyd {
+		return nil, fmt.Errorf("%w: size constraints require %s, got %s", ErrAlgorithmNotSupported, Lloyd, cfg.algorithm)
+	}
+
+	n := len(points)
+	minSize, maxSize := bounds(n, cfg.k)
+	if minSize < 0 || maxSize < max(minSize, 1) || minSize*cfg.k > n || maxSize*cfg.k < n {
+		return nil, fmt.Errorf("%w: %d clusters of %d to %d points for %d points",
+			ErrInvalidSizeBounds, cfg.k, minSize, maxSize, n)
+	}
+
+	cfg.minSize, cfg.maxSize, cfg.constrained = minSize, maxSize, true
+	if cfg.emptyClusters != FailOnEmptyCluster {
+		cfg.emptyClusters = KeepCentroid
+	}
+
+	return fit(ctx, points, &cfg)
+}
+
+// constrained is the assignment step of size-constrained k-means.
+type constrained struct {
+	points      []Point
+	assignments []int
+	cfg         *config
+}
+
+func (c *constrained) assign(ctx context.Context, centroids []Point) (bool, error) {
+	costs := make([][]float64, len(c.points))
+	_, _ = parallelPass(ctx, len(c.points), c.cfg.workers, func(i int) bool {
+		costs[i] = make([]float64, len(centroids))
+		for j, centroid := range centroids {
+			costs[i][j] = weightOf(c.cfg.weights, i) * squaredError(c.cfg.metric, c.points[i], centroid)
+		}
+		return false
+	})
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	flow := newAssignmentFlow(costs, c.cfg.minSize, c.cfg.maxSize)
+	for i := range c.points {
+		if i > 0 && i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+		}
+		flow.add(i)
+	}
+
+	changed := false
+	for i, a := range flow.cluster {
+		if c.assignments[i] != a {
+			c.assignments[i] = a
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+func (c *constrained) moved(_, _ []Point) {}
+
+func (c *constrained) reassigned(int) {}
+
+// move is a candidate to move a point to another cluster, with the change of the cost it causes.
+type move struct {
+	delta float64
+	point int
+}
+
+// moveHeap orders the moves by cost change, then by point index.
+type moveHeap []move
+
+func (h moveHeap) Len() int { return len(h) }
+func (h moveHeap) Less(i, j int) bool {
+	return h[i].delta < h[j].delta || (h[i].delta == h[j].delta && h[i].point < h[j].point)
+}
+func (h moveHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *moveHeap) Push(x any)   { *h = append(*h, x.(move)) }
+func (h *moveHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// assignmentFlow is a minimum-cost assignment of points to clusters of bounded sizes, built one
+// point at a time. After every added point the assignment of the points added so far is optimal,
+// so the residual graph between the clusters has no negative cycle, and node potentials keep the
+// reduced cost of every move non-negative: shortest paths can be found with Dijkstra.
+//
+// Reaching a cluster below minSize always beats reaching one that is already large enough, which
+// is the lexicographic cost of a flow with a large bonus for the first minSize points of every
+// cluster: all minimum sizes are met at the end, at the lowest cost that allows it.
+type assignmentFlow struct {
+	costs            [][]float64 // cost of assigning every point to every cluster
+	cluster          []int       // cluster of every point, -1 until it is added
+	sizes            []int
+	minSize, maxSize int
+	moves            [][]moveHeap // moves[a][b] holds the points of cluster a by the cost of moving them to b
+	potentials       []float64    // cost of the cheapest path into every cluster, up to a constant
+	dist             []float64    // reduced cost of the cheapest path from the added point into every cluster
+	prev             []int        // previous cluster on that path, -1 if the added point joins it directly
+	done             []bool       // clusters whose distance is final
+}
+
+func newAssignmentFlow(costs [][]float64, minSize, maxSize int) *assignmentFlow {
+	k := len(costs[0])
+	f := &assignmentFlow{
+		costs:      costs,
+		cluster:    make([]int, len(costs)),
+		sizes:      make([]int, k),
+		minSize:    minSize,
+		maxSize:    maxSize,
+		moves:      make([][]moveHeap, k),
+		potentials: make([]float64, k),
+		dist:       make([]float64, k),
+		prev:       make([]int, k),
+		done:       make([]bool, k),
+	}
+	for i := range f.cluster {
+		f.cluster[i] = -1
+	}
+	for a := range f.moves {
+		f.moves[a] = make([]moveHeap, k)
+	}
+
+	return f
+}
+
+// place puts point i into cluster a and records its possible moves to the other clusters.
+// Entries of its previous cluster become stale and are dropped lazily by cheapestMove.
+func (f *assignmentFlow) place(i, a int) {
+	if f.cluster[i] >= 0 {
+		f.sizes[f.cluster[i]]--
+	}
+	f.cluster[i] = a
+	f.sizes[a]++
+
+	for b := range f.sizes {
+		if b != a {
+			heap.Push(&f.moves[a][b], move{delta: f.costs[i][b] - f.costs[i][a], point: i})
+		}
+	}
+}
+
+// cheapestMove returns the point of cluster a that is cheapest to move to cluster b.
+func (f *assignmentFlow) cheapestMove(a, b int) (move, bool) {
+	h := &f.moves[a][b]
+	for h.Len() > 0 {
+		if top := (*h)[0]; f.cluster[top.point] == a {
+			return top, true
+		}
+		heap.Pop(h)
+	}
+
+	return move{}, false
+}
+
+// add assigns point i along the cheapest augmenting path: the point joins some cluster, which passes
+// one of its points on to another cluster and so on, until a cluster with room grows by one point.
+func (f *assignmentFlow) add(i int) {
+	f.shortestPaths(i)
+
+	// Shift the points along the path, starting at its end, then place the new point
+	b := f.target()
+	for steps := 0; f.prev[b] >= 0 && steps < len(f.sizes); steps++ {
+		a := f.prev[b]
+		m, _ := f.cheapestMove(a, b)
+		f.place(m.point, b)
+		b = a
+	}
+	f.place(i, b)
+}
+
+// shortestPaths runs Dijkstra from point i over the clusters in O(k²) steps, on the costs reduced
+// by the potentials, then adds the distances to the potentials. The new potentials are the costs
+// of the cheapest paths from point i up to a constant, so they remain valid once the path is used.
+//
+// Formula:
+//
+//	reduced(a → b) = cost(a → b) + potential(a) - potential(b) >= 0
+func (f *assignmentFlow) shortestPaths(i int) {
+	offset := math.Inf(1)
+	for b, potential := range f.potentials {
+		offset = min(offset, f.costs[i][b]-potential)
+	}
+	for b, potential := range f.potentials {
+		f.dist[b], f.prev[b], f.done[b] = f.costs[i][b]-potential-offset, -1, false
+	}
+
+	for range f.sizes {
+		a := f.closest()
+		f.done[a] = true
+		for b := range f.sizes {
+			if f.done[b] {
+				continue
+			}
+			if m, ok := f.cheapestMove(a, b); ok {
+				// Rounding can make a reduced cost slightly negative
+				reduced := max(m.delta+f.potentials[a]-f.potentials[b], 0)
+				if d := f.dist[a] + reduced; d < f.dist[b] {
+					f.dist[b], f.prev[b] = d, a
+				}
+			}
+		}
+	}
+
+	for b, d := range f.dist {
+		f.potentials[b] += d
+	}
+}
+
+// closest returns the cluster with the smallest distance that is not final yet.
+func (f *assignmentFlow) closest() int {
+	closest := -1
+	for b, d := range f.dist {
+		if !f.done[b] && (closest < 0 || d < f.dist[closest]) {
+			closest = b
+		}
+	}
+
+	return closest
+}
+
+// target returns the cluster with room that is cheapest to reach after shortestPaths,
+// preferring those still below the minimum size.
+func (f *assignmentFlow) target() int {
+	target := -1
+	for b, size := range f.sizes {
+		if size >= f.maxSize {
+			continue
+		}
+		if target < 0 || f.below(b) && !f.below(target) ||
+			f.below(b) == f.below(target) && f.potentials[b] < f.potentials[target] {
+			target = b
+		}
+	}
+
+	return target
+}
+
+// below reports whether cluster b has fewer points than the minimum size.
+func (f *assignmentFlow) below(b int) bool {
+	return f.sizes[b] < f.minSize
+}
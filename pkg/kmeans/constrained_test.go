@@ -0,0 +1,189 @@
+package kmeans_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConstrainedSuite struct {
+	suite.Suite
+}
+
+func TestConstrainedSuite(t *testing.T) {
+	suite.Run(t, new(ConstrainedSuite))
+}
+
+// unevenBlobs returns a dense blob of 150 points next to two small ones of 25 points.
+func unevenBlobs() []kmeans.Point {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}}, 150, 2, 1)
+	return append(points, gaussianBlobs([]kmeans.Point{{12, 0}, {0, 12}}, 25, 1, 2)...)
+}
+
+// cheapestAssignment enumerates every assignment of the points to the centroids with cluster sizes
+// between minSize and maxSize, and returns the lowest SSE among them.
+func cheapestAssignment(points, centroids []kmeans.Point, minSize, maxSize int) float64 {
+	k := len(centroids)
+	assignments := make([]int, len(points))
+	best := math.Inf(1)
+
+	var visit func(i int)
+	visit = func(i int) {
+		if i == len(points) {
+			sizes := make([]int, k)
+			for _, a := range assignments {
+				sizes[a]++
+			}
+			for _, size := range sizes {
+				if size < minSize || size > maxSize {
+					return
+				}
+			}
+			best = min(best, kmeans.CalculateSSE(points, centroids, assignments))
+			return
+		}
+		for a := range k {
+			assignments[i] = a
+			visit(i + 1)
+		}
+	}
+	visit(0)
+
+	return best
+}
+
+func (s *ConstrainedSuite) TestSizesStayWithinBounds() {
+	points := unevenBlobs()
+
+	unconstrained, err := kmeans.Fit(points, kmeans.WithK(4), kmeans.WithSeed(3))
+	s.Require().NoError(err)
+	s.Greater(slices.Max(unconstrained.ClusterSizes), 60)
+
+	result, err := kmeans.SizeConstrainedKMeans(points, 40, 60, kmeans.WithK(4), kmeans.WithSeed(3))
+	s.Require().NoError(err)
+
+	for _, size := range result.ClusterSizes {
+		s.GreaterOrEqual(size, 40)
+		s.LessOrEqual(size, 60)
+	}
+	s.InDelta(kmeans.CalculateSSE(points, result.Centroids, result.Assignments), result.SSE, 1e-9)
+}
+
+func (s *ConstrainedSuite) TestBalancedSizes() {
+	points := unevenBlobs()
+
+	result, err := kmeans.BalancedKMeans(points, kmeans.WithK(7), kmeans.WithSeed(4))
+	s.Require().NoError(err)
+
+	for _, size := range result.ClusterSizes {
+		s.Contains([]int{28, 29}, size) // 200 points in 7 clusters
+	}
+}
+
+func (s *ConstrainedSuite) TestAssignmentIsOptimal() {
+	rng := rand.New(rand.NewSource(5))
+	for trial := range 20 {
+		points := make([]kmeans.Point, 9)
+		for i := range points {
+			points[i] = kmeans.Point{10 * rng.Float64(), 10 * rng.Float64()}
+		}
+		centroids := []kmeans.Point{points[0], points[1], {5, 5}}
+		start := func(_ []kmeans.Point, _ int) []kmeans.Point { return centroids }
+		minSize, maxSize := 2, 4
+		if trial%2 == 1 {
+			minSize, maxSize = 3, 3
+		}
+
+		result, err := kmeans.SizeConstrainedKMeans(points, minSize, maxSize,
+			kmeans.WithK(3), kmeans.WithInitializer(start), kmeans.WithMaxIterations(1))
+		s.Require().NoError(err)
+
+		expected := cheapestAssignment(points, centroids, minSize, maxSize)
+		s.InDelta(expected, kmeans.CalculateSSE(points, centroids, result.Assignments), 1e-9, "trial %d", trial)
+	}
+}
+
+func (s *ConstrainedSuite) TestAssignmentIsOptimalWithLongPaths() {
+	// With four tight clusters a new point often has to push others along a chain of clusters
+	rng := rand.New(rand.NewSource(6))
+	for trial := range 10 {
+		points := make([]kmeans.Point, 8)
+		for i := range points {
+			points[i] = kmeans.Point{10 * rng.Float64(), 10 * rng.Float64()}
+		}
+		centroids := []kmeans.Point{{0, 0}, {10, 0}, {0, 10}, {10, 10}}
+		start := func(_ []kmeans.Point, _ int) []kmeans.Point { return centroids }
+
+		result, err := kmeans.BalancedKMeans(points,
+			kmeans.WithK(4), kmeans.WithInitializer(start), kmeans.WithMaxIterations(1))
+		s.Require().NoError(err)
+
+		expected := cheapestAssignment(points, centroids, 2, 2)
+		s.InDelta(expected, kmeans.CalculateSSE(points, centroids, result.Assignments), 1e-9, "trial %d", trial)
+	}
+}
+
+func (s *ConstrainedSuite) TestLooseBoundsMatchFit() {
+	points := fourBlobs()
+
+	fit, err := kmeans.Fit(points, kmeans.WithK(4), kmeans.WithSeed(6))
+	s.Require().NoError(err)
+	constrained, err := kmeans.SizeConstrainedKMeans(points, 0, len(points), kmeans.WithK(4), kmeans.WithSeed(6))
+	s.Require().NoError(err)
+
+	s.Equal(fit.Assignments, constrained.Assignments)
+	s.Equal(fit.Iterations, constrained.Iterations)
+	s.InDelta(fit.SSE, constrained.SSE, 1e-9)
+}
+
+func (s *ConstrainedSuite) TestWeightedCosts() {
+	points := []kmeans.Point{{0, 0}, {1, 0}, {9, 0}, {10, 0}}
+	weights := []float64{1, 1, 1, 10}
+	start := func(_ []kmeans.Point, _ int) []kmeans.Point { return []kmeans.Point{{0, 0}, {10, 0}} }
+
+	// Cluster 1 has room for one point only, and the heavy point keeps it
+	result, err := kmeans.SizeConstrainedKMeans(points, 1, 3, kmeans.WithK(2), kmeans.WithInitializer(start),
+		kmeans.WithWeights(weights), kmeans.WithMaxIterations(1))
+	s.Require().NoError(err)
+	s.Equal(1, result.Assignments[3])
+}
+
+func (s *ConstrainedSuite) TestCancelledContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := kmeans.SizeConstrainedKMeansContext(ctx, fourBlobs(), 50, 100, kmeans.WithK(4))
+	s.ErrorIs(err, context.Canceled)
+
+	result, err := kmeans.BalancedKMeansContext(ctx, fourBlobs(), kmeans.WithK(4))
+	s.ErrorIs(err, context.Canceled)
+	s.Require().NotNil(result)
+	s.Equal(kmeans.StopCancelled, result.StopReason)
+}
+
+func (s *ConstrainedSuite) TestInvalidInputReturnsError() {
+	points := twoBlobs()
+
+	_, err := kmeans.SizeConstrainedKMeans(points, 4, 6, kmeans.WithK(2))
+	s.ErrorIs(err, kmeans.ErrInvalidSizeBounds)
+
+	_, err = kmeans.SizeConstrainedKMeans(points, 1, 2, kmeans.WithK(2))
+	s.ErrorIs(err, kmeans.ErrInvalidSizeBounds)
+
+	_, err = kmeans.SizeConstrainedKMeans(points, 3, 2, kmeans.WithK(2))
+	s.ErrorIs(err, kmeans.ErrInvalidSizeBounds)
+
+	_, err = kmeans.BalancedKMeans(points, kmeans.WithK(2), kmeans.WithAlgorithm(kmeans.Elkan))
+	s.ErrorIs(err, kmeans.ErrAlgorithmNotSupported)
+
+	_, err = kmeans.BalancedKMeans(points, kmeans.WithK(2), kmeans.WithMetric(kmeans.Manhattan))
+	s.ErrorIs(err, kmeans.ErrMetricNotMeanCompatible)
+
+	_, err = kmeans.BalancedKMeans(points)
+	s.ErrorIs(err, kmeans.ErrNegativeNumberOfClusters)
+}
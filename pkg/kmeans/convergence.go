@@ -0,0 +1,90 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// StopReason tells which criterion ended a clustering run.
+type StopReason int
+
+const (
+	// StopMaxIterations means the iteration cap was reached before any other criterion was met.
+	StopMaxIterations StopReason = iota
+	// StopAssignmentsStable means the last pass did not move any point to a different cluster.
+	StopAssignmentsStable
+	// StopCentroidShift means the largest centroid movement dropped below the configured tolerance.
+	StopCentroidShift
+	// StopSSEImprovement means the relative SSE improvement dropped below the configured threshold.
+	StopSSEImprovement
+	// StopCancelled means the context of the run was cancelled or its deadline passed.
+	StopCancelled
+	// StopNoImprovement means the smoothed mini-batch error did not improve for the configured number of batches.
+	StopNoImprovement
+)
+
+// String returns a human-readable name of the stop reason.
+func (r StopReason) String() string {
+	switch r {
+	case StopMaxIterations:
+		return "max iterations"
+	case StopAssignmentsStable:
+		return "assignments stable"
+	case StopCentroidShift:
+		return "centroid shift below tolerance"
+	case StopSSEImprovement:
+		return "SSE improvement below threshold"
+	case StopCancelled:
+		return "cancelled"
+	case StopNoImprovement:
+		return "no improvement"
+	default:
+		return fmt.Sprintf("StopReason(%d)", int(r))
+	}
+}
+
+// convergenceCheck evaluates the early stopping criteria after every iteration.
+// It remembers the SSE of the previous iteration for the relative improvement test.
+type convergenceCheck struct {
+	tolerance    float64 // maximum centroid shift considered as "not moving"
+	sseTolerance float64 // minimum relative SSE improvement, 0 disables the test
+	previousSSE  float64
+}
+
+// newConvergenceCheck creates a check using the tolerances from the run configuration.
+func newConvergenceCheck(cfg *config) *convergenceCheck {
+	return &convergenceCheck{
+		tolerance:    cfg.tolerance,
+		sseTolerance: cfg.sseTolerance,
+		previousSSE:  math.Inf(1),
+	}
+}
+
+// stop reports whether the run should end and why.
+//
+// Arguments:
+//   - changed: whether any assignment changed in the last pass
+//   - shift: the largest distance a centroid moved in the last update
+//   - sse: lazily computes the SSE of the current partition, called only when the SSE test is enabled
+func (c *convergenceCheck) stop(changed bool, shift float64, sse func() float64) (StopReason, bool) {
+	if !changed {
+		return StopAssignmentsStable, true
+	}
+
+	if shift < c.tolerance {
+		return StopCentroidShift, true
+	}
+
+	if c.sseTolerance > 0 {
+		previous := c.previousSSE
+		current := sse()
+		c.previousSSE = current
+
+		// Relative improvement: (SSE_prev - SSE) / SSE_prev <= threshold
+		if !math.IsInf(previous, 1) && previous-current <= c.sseTolerance*previous {
+			return StopSSEImprovement, true
+		}
+	}
+
+	return StopMaxIterations, false
+}
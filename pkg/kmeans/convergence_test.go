@@ -0,0 +1,83 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConvergenceSuite struct {
+	suite.Suite
+}
+
+func TestConvergenceSuite(t *testing.T) {
+	suite.Run(t, new(ConvergenceSuite))
+}
+
+// line returns evenly spaced 1D points, a dataset on which Lloyd's algorithm moves slowly
+// when both initial centroids start at the same end.
+func line(n int) []kmeans.Point {
+	points := make([]kmeans.Point, n)
+	for i := range points {
+		points[i] = kmeans.Point{float64(i)}
+	}
+	return points
+}
+
+func (s *ConvergenceSuite) TestStopsWhenAssignmentsStable() {
+	result, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithInitializer(firstPoints))
+	s.Require().NoError(err)
+
+	s.True(result.Converged)
+	s.Equal(kmeans.StopAssignmentsStable, result.StopReason)
+	s.Less(result.Iterations, 300)
+}
+
+func (s *ConvergenceSuite) TestStopsOnCentroidShift() {
+	points := line(100)
+	exact, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithInitializer(firstPoints))
+	s.Require().NoError(err)
+
+	loose, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithInitializer(firstPoints), kmeans.WithTolerance(5))
+	s.Require().NoError(err)
+
+	s.True(loose.Converged)
+	s.Equal(kmeans.StopCentroidShift, loose.StopReason)
+	s.Less(loose.Iterations, exact.Iterations)
+}
+
+func (s *ConvergenceSuite) TestStopsOnSSEImprovement() {
+	points := line(100)
+	exact, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithInitializer(firstPoints))
+	s.Require().NoError(err)
+
+	loose, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithInitializer(firstPoints), kmeans.WithSSETolerance(0.2))
+	s.Require().NoError(err)
+
+	s.True(loose.Converged)
+	s.Equal(kmeans.StopSSEImprovement, loose.StopReason)
+	s.Less(loose.Iterations, exact.Iterations)
+}
+
+func (s *ConvergenceSuite) TestMaxIterationsReported() {
+	result, err := kmeans.Fit(line(100), kmeans.WithK(2), kmeans.WithInitializer(firstPoints), kmeans.WithMaxIterations(2))
+	s.Require().NoError(err)
+
+	s.False(result.Converged)
+	s.Equal(kmeans.StopMaxIterations, result.StopReason)
+	s.Equal(2, result.Iterations)
+}
+
+func (s *ConvergenceSuite) TestNegativeToleranceReturnsError() {
+	_, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithTolerance(-1))
+	s.ErrorIs(err, kmeans.ErrInvalidTolerance)
+
+	_, err = kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithSSETolerance(-0.1))
+	s.ErrorIs(err, kmeans.ErrInvalidTolerance)
+}
+
+func (s *ConvergenceSuite) TestStopReasonString() {
+	s.Equal("assignments stable", kmeans.StopAssignmentsStable.String())
+	s.Equal("StopReason(42)", kmeans.StopReason(42).String())
+}
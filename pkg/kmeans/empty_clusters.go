@@ -0,0 +1,129 @@
+package kmeans
+
+import "fmt"
+
+// EmptyClusterStrategy decides what happens when a cluster receives no points in an assignment pass.
+type EmptyClusterStrategy int
+
+const (
+	// KeepCentroid leaves the centroid of an empty cluster where it is. It is the default.
+	KeepCentroid EmptyClusterStrategy = iota
+	// ReseedFarthestPoint moves the centroid onto the point farthest from its own centroid.
+	ReseedFarthestPoint
+	// ReseedLargestSSECluster moves the centroid onto the farthest point of the cluster with the largest SSE.
+	ReseedLargestSSECluster
+	// ReseedPlusPlus moves the centroid onto a point sampled k-means++ style,
+	// with probability proportional to its squared distance to its own centroid.
+	ReseedPlusPlus
+	// FailOnEmptyCluster stops the run with ErrEmptyCluster.
+	FailOnEmptyCluster
+)
+
+// String returns a human-readable name of the strategy.
+func (s EmptyClusterStrategy) String() string {
+	switch s {
+	case KeepCentroid:
+		return "keep centroid"
+	case ReseedFarthestPoint:
+		return "reseed farthest point"
+	case ReseedLargestSSECluster:
+		return "reseed from largest SSE cluster"
+	case ReseedPlusPlus:
+		return "reseed k-means++"
+	case FailOnEmptyCluster:
+		return "fail"
+	default:
+		return fmt.Sprintf("EmptyClusterStrategy(%d)", int(s))
+	}
+}
+
+// handleEmptyClusters applies the configured strategy to every cluster left without points
+// by the last assignment pass. A reseeded cluster takes over the chosen point, which is
+// never the only point of its own cluster, so reseeding cannot empty another cluster.
+//
+// It returns the number of empty clusters found and the indices of the points that changed
+// cluster; the error is ErrEmptyCluster when the strategy is FailOnEmptyCluster.
+func handleEmptyClusters(points, centroids []Point, assignments []int, cfg *config) (int, []int, error) {
+	counts := clusterSizes(assignments, len(centroids))
+	var empty []int
+	for j, count := range counts {
+		if count == 0 {
+			empty = append(empty, j)
+		}
+	}
+
+	switch {
+	case len(empty) == 0 || cfg.emptyClusters == KeepCentroid:
+		return len(empty), nil, nil
+	case cfg.emptyClusters == FailOnEmptyCluster:
+		return len(empty), nil, fmt.Errorf("%w: cluster %d", ErrEmptyCluster, empty[0])
+	}
+
+	// Weighted squared error of every point with respect to its current centroid
+	errs := make([]float64, len(points))
+	for i, point := range points {
+		errs[i] = weightOf(cfg.weights, i) * squaredError(cfg.metric, point, centroids[assignments[i]])
+	}
+
+	moved := make([]int, 0, len(empty))
+	for _, j := range empty {
+		i := pickReseedPoint(errs, counts, assignments, cfg)
+
+		counts[assignments[i]]--
+		counts[j]++
+		assignments[i] = j
+		centroids[j] = append(Point(nil), points[i]...)
+		errs[i] = 0
+		moved = append(moved, i)
+	}
+
+	return len(empty), moved, nil
+}
+
+// pickReseedPoint chooses the point that becomes the new centroid of an empty cluster.
+// Only points of clusters with more than one point are eligible.
+func pickReseedPoint(errs []float64, counts, assignments []int, cfg *config) int {
+	eligible := func(i int) bool { return counts[assignments[i]] > 1 }
+
+	switch cfg.emptyClusters {
+	case ReseedLargestSSECluster:
+		clusterSSE := make([]float64, len(counts))
+		for i, e := range errs {
+			clusterSSE[assignments[i]] += e
+		}
+
+		largest := -1
+		for c, sse := range clusterSSE {
+			if counts[c] > 1 && (largest < 0 || sse > clusterSSE[largest]) {
+				largest = c
+			}
+		}
+
+		return farthest(errs, func(i int) bool { return assignments[i] == largest })
+	case ReseedPlusPlus:
+		weights := make([]float64, len(errs))
+		for i, e := range errs {
+			if eligible(i) {
+				weights[i] = e
+			}
+		}
+
+		if i := sampleProportional(cfg.rng, weights); i >= 0 {
+			return i
+		}
+	}
+
+	return farthest(errs, eligible)
+}
+
+// farthest returns the eligible point with the largest error, the lowest index on ties.
+func farthest(errs []float64, eligible func(i int) bool) int {
+	best := -1
+	for i, e := range errs {
+		if eligible(i) && (best < 0 || e > errs[best]) {
+			best = i
+		}
+	}
+
+	return best
+}
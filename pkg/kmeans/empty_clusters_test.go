@@ -0,0 +1,102 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmptyClustersSuite struct {
+	suite.Suite
+}
+
+func TestEmptyClustersSuite(t *testing.T) {
+	suite.Run(t, new(EmptyClustersSuite))
+}
+
+// farAwayThird returns initial centroids of which the third one never gets any point of line(4)-like data.
+func farAwayThird(_ []kmeans.Point, _ int) []kmeans.Point {
+	return []kmeans.Point{{0}, {0.2}, {100}}
+}
+
+// duplicateFirst returns k copies of the first point, so all but one cluster start empty.
+func duplicateFirst(points []kmeans.Point, k int) []kmeans.Point {
+	centroids := make([]kmeans.Point, k)
+	for i := range centroids {
+		centroids[i] = points[0]
+	}
+	return centroids
+}
+
+func emptyClusterPoints() []kmeans.Point {
+	return []kmeans.Point{{0}, {1}, {10}, {11}}
+}
+
+func (s *EmptyClustersSuite) TestKeepCentroidIsDefault() {
+	result, err := kmeans.Fit(emptyClusterPoints(), kmeans.WithK(3), kmeans.WithInitializer(farAwayThird))
+	s.Require().NoError(err)
+
+	s.Positive(result.EmptyClusters)
+	s.Equal(kmeans.Point{100}, result.Centroids[2])
+	s.Equal(0, result.ClusterSizes[2])
+}
+
+func (s *EmptyClustersSuite) TestReseedStrategiesFillEveryCluster() {
+	strategies := []kmeans.EmptyClusterStrategy{
+		kmeans.ReseedFarthestPoint, kmeans.ReseedLargestSSECluster, kmeans.ReseedPlusPlus,
+	}
+
+	for _, strategy := range strategies {
+		result, err := kmeans.Fit(emptyClusterPoints(), kmeans.WithK(3), kmeans.WithSeed(1),
+			kmeans.WithInitializer(farAwayThird), kmeans.WithEmptyClusterStrategy(strategy))
+		s.Require().NoError(err)
+
+		s.Positive(result.EmptyClusters, strategy.String())
+		s.NotContains(result.ClusterSizes, 0, strategy.String())
+		s.InDelta(0.5, result.SSE, 1e-9, strategy.String())
+	}
+}
+
+func (s *EmptyClustersSuite) TestReseedFarthestPointTakesFarthestPoint() {
+	result, err := kmeans.Fit(emptyClusterPoints(), kmeans.WithK(3), kmeans.WithMaxIterations(1),
+		kmeans.WithInitializer(farAwayThird), kmeans.WithEmptyClusterStrategy(kmeans.ReseedFarthestPoint))
+	s.Require().NoError(err)
+
+	s.Equal(kmeans.Point{11}, result.Centroids[2])
+	s.Equal([]int{0, 1, 1, 2}, result.Assignments)
+}
+
+func (s *EmptyClustersSuite) TestFailOnEmptyCluster() {
+	result, err := kmeans.Fit(emptyClusterPoints(), kmeans.WithK(3),
+		kmeans.WithInitializer(farAwayThird), kmeans.WithEmptyClusterStrategy(kmeans.FailOnEmptyCluster))
+	s.ErrorIs(err, kmeans.ErrEmptyCluster)
+	s.Require().NotNil(result)
+	s.Equal(1, result.EmptyClusters)
+}
+
+func (s *EmptyClustersSuite) TestAcceleratedReseedingMatchesLloyd() {
+	points := randomPoints(800, 3, 12)
+	opts := []kmeans.Option{
+		kmeans.WithK(6), kmeans.WithInitializer(duplicateFirst),
+		kmeans.WithEmptyClusterStrategy(kmeans.ReseedFarthestPoint),
+	}
+
+	lloyd, err := kmeans.Fit(points, opts...)
+	s.Require().NoError(err)
+	s.Equal(5, lloyd.EmptyClusters)
+
+	for _, algorithm := range []kmeans.Algorithm{kmeans.Elkan, kmeans.Hamerly} {
+		accelerated, err := kmeans.Fit(points, append(opts, kmeans.WithAlgorithm(algorithm))...)
+		s.Require().NoError(err)
+
+		s.Equal(lloyd.Centroids, accelerated.Centroids, algorithm.String())
+		s.Equal(lloyd.Assignments, accelerated.Assignments, algorithm.String())
+		s.Equal(lloyd.EmptyClusters, accelerated.EmptyClusters, algorithm.String())
+	}
+}
+
+func (s *EmptyClustersSuite) TestUnknownStrategyReturnsError() {
+	_, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithEmptyClusterStrategy(kmeans.EmptyClusterStrategy(42)))
+	s.ErrorIs(err, kmeans.ErrUnknownEmptyClusterStrategy)
+}
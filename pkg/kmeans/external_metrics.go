@@ -0,0 +1,332 @@
+package kmeans
+
+import "math"
+
+// chanceTolerance is the relative margin below which the mutual information left above chance counts as zero.
+const chanceTolerance = 1e-10
+
+// ContingencyTable counts how the points of every ground-truth class are spread over the clusters.
+// Class and cluster indices are used as given, so labels that never occur give empty rows or columns.
+type ContingencyTable struct {
+	Counts       [][]int // Counts[class][cluster] is the number of points of the class in the cluster
+	ClassSizes   []int   // number of points of every class (row sums)
+	ClusterSizes []int   // number of points of every cluster (column sums)
+	N            int     // total number of points
+}
+
+// NewContingencyTable builds the contingency table of the ground-truth labels
+// and the cluster assignments of the same points.
+//
+// Arguments:
+//   - labels: true class of each point, non-negative
+//   - assignments: index of the cluster assigned to each point, as returned by KMeans
+//
+// Returns:
+//   - table: counts of points per class and cluster, with both marginals
+//   - err: ErrNoPoints, ErrLabelsMismatch, ErrInvalidLabel or ErrInvalidAssignment for invalid input
+func NewContingencyTable(labels, assignments []int) (*ContingencyTable, error) {
+	if len(labels) == 0 {
+		return nil, ErrNoPoints
+	}
+
+	if len(labels) != len(assignments) {
+		return nil, ErrLabelsMismatch
+	}
+
+	classes, clusters := 0, 0
+	for i := range labels {
+		if labels[i] < 0 {
+			return nil, ErrInvalidLabel
+		}
+		if assignments[i] < 0 {
+			return nil, ErrInvalidAssignment
+		}
+		classes = max(classes, labels[i]+1)
+		clusters = max(clusters, assignments[i]+1)
+	}
+
+	table := &ContingencyTable{
+		Counts:       make([][]int, classes),
+		ClassSizes:   make([]int, classes),
+		ClusterSizes: make([]int, clusters),
+		N:            len(labels),
+	}
+	for c := range table.Counts {
+		table.Counts[c] = make([]int, clusters)
+	}
+
+	for i, class := range labels {
+		table.Counts[class][assignments[i]]++
+		table.ClassSizes[class]++
+		table.ClusterSizes[assignments[i]]++
+	}
+
+	return table, nil
+}
+
+// AdjustedRandIndex calculates the Rand index of the clustering adjusted for chance.
+// It is 1 for identical partitions, close to 0 for random ones and can be negative.
+//
+// Formula:
+//
+//	index    = Σ_ij C(n_ij, 2)
+//	expected = Σ_i C(a_i, 2) · Σ_j C(b_j, 2) / C(n, 2)
+//	ARI      = (index - expected) / ((Σ_i C(a_i, 2) + Σ_j C(b_j, 2)) / 2 - expected)
+//
+// where a_i and b_j are the class and cluster sizes. Two trivial partitions
+// (one cluster each, or singletons only) score 1.
+func AdjustedRandIndex(labels, assignments []int) (float64, error) {
+	table, err := NewContingencyTable(labels, assignments)
+	if err != nil {
+		return 0, err
+	}
+
+	index := 0.0
+	for _, row := range table.Counts {
+		for _, count := range row {
+			index += pairs(count)
+		}
+	}
+
+	classPairs, clusterPairs := sumPairs(table.ClassSizes), sumPairs(table.ClusterSizes)
+	if table.N == 1 {
+		return 1, nil // there are no pairs to compare
+	}
+
+	expected := classPairs * clusterPairs / pairs(table.N)
+	maximum := (classPairs + clusterPairs) / 2
+	if maximum == expected {
+		return 1, nil
+	}
+
+	return (index - expected) / (maximum - expected), nil
+}
+
+// NormalizedMutualInformation calculates the mutual information of the labels and the clusters
+// divided by the arithmetic mean of their entropies. It is in [0, 1], 1 for identical partitions.
+//
+// Formula:
+//
+//	MI  = Σ_ij (n_ij / n) ln(n · n_ij / (a_i · b_j))
+//	NMI = MI / ((H(labels) + H(clusters)) / 2)
+//
+// Two partitions with a single group each score 1.
+func NormalizedMutualInformation(labels, assignments []int) (float64, error) {
+	table, err := NewContingencyTable(labels, assignments)
+	if err != nil {
+		return 0, err
+	}
+
+	classEntropy, clusterEntropy := entropy(table.ClassSizes, table.N), entropy(table.ClusterSizes, table.N)
+	if classEntropy == 0 && clusterEntropy == 0 {
+		return 1, nil
+	}
+
+	return mutualInformation(table) / ((classEntropy + clusterEntropy) / 2), nil
+}
+
+// AdjustedMutualInformation calculates the mutual information adjusted for chance,
+// normalized by the arithmetic mean of the entropies. It is 1 for identical partitions
+// and close to 0 for random ones, whatever the number of clusters.
+//
+// Formula:
+//
+//	AMI = (MI - E[MI]) / ((H(labels) + H(clusters)) / 2 - E[MI])
+//
+// E[MI] is the expected mutual information of random partitions with the same group sizes
+// (hypergeometric model). Two partitions with a single group each, or with singletons only, score 1.
+func AdjustedMutualInformation(labels, assignments []int) (float64, error) {
+	table, err := NewContingencyTable(labels, assignments)
+	if err != nil {
+		return 0, err
+	}
+
+	classEntropy, clusterEntropy := entropy(table.ClassSizes, table.N), entropy(table.ClusterSizes, table.N)
+	if classEntropy == 0 && clusterEntropy == 0 {
+		return 1, nil
+	}
+
+	normalizer := (classEntropy + clusterEntropy) / 2
+	expected := expectedMutualInformation(table)
+	if normalizer-expected <= chanceTolerance*normalizer {
+		// Only two partitions into singletons are as informative as chance, and they are identical
+		return 1, nil
+	}
+
+	return (mutualInformation(table) - expected) / (normalizer - expected), nil
+}
+
+// VMeasureResult holds the entropy-based scores of a clustering against ground-truth labels.
+type VMeasureResult struct {
+	Homogeneity  float64 // 1 when every cluster only holds points of a single class
+	Completeness float64 // 1 when all points of every class are in the same cluster
+	VMeasure     float64 // harmonic mean of homogeneity and completeness
+}
+
+// VMeasure calculates the homogeneity, completeness and V-measure of the clustering.
+//
+// Formula:
+//
+//	homogeneity  = 1 - H(labels | clusters) / H(labels)
+//	completeness = 1 - H(clusters | labels) / H(clusters)
+//	V            = 2 · homogeneity · completeness / (homogeneity + completeness)
+//
+// A partition with a single group has zero entropy and counts as perfectly homogeneous or complete.
+func VMeasure(labels, assignments []int) (*VMeasureResult, error) {
+	table, err := NewContingencyTable(labels, assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	mi := mutualInformation(table)
+	classEntropy, clusterEntropy := entropy(table.ClassSizes, table.N), entropy(table.ClusterSizes, table.N)
+
+	// H(labels | clusters) = H(labels) - MI, so homogeneity = MI / H(labels)
+	result := &VMeasureResult{Homogeneity: 1, Completeness: 1}
+	if classEntropy > 0 {
+		result.Homogeneity = mi / classEntropy
+	}
+	if clusterEntropy > 0 {
+		result.Completeness = mi / clusterEntropy
+	}
+	if result.Homogeneity+result.Completeness > 0 {
+		result.VMeasure = 2 * result.Homogeneity * result.Completeness / (result.Homogeneity + result.Completeness)
+	}
+
+	return result, nil
+}
+
+// FowlkesMallows calculates the geometric mean of the pairwise precision and recall:
+// of all pairs of points in the same class, and in the same cluster, how many are in both.
+//
+// Formula:
+//
+//	TP = Σ_ij C(n_ij, 2)
+//	FM = TP / sqrt(Σ_i C(a_i, 2) · Σ_j C(b_j, 2))
+//
+// It is 0 when no pair of points shares both a class and a cluster.
+func FowlkesMallows(labels, assignments []int) (float64, error) {
+	table, err := NewContingencyTable(labels, assignments)
+	if err != nil {
+		return 0, err
+	}
+
+	truePositives := 0.0
+	for _, row := range table.Counts {
+		for _, count := range row {
+			truePositives += pairs(count)
+		}
+	}
+
+	if truePositives == 0 {
+		return 0, nil
+	}
+
+	return truePositives / math.Sqrt(sumPairs(table.ClassSizes)*sumPairs(table.ClusterSizes)), nil
+}
+
+// Purity calculates the fraction of points that belong to the majority class of their cluster.
+// It is in (0, 1], but trivially reaches 1 with one cluster per point, so it should only compare
+// clusterings with the same number of clusters.
+//
+// Formula:
+//
+//	purity = (1 / n) Σ_j max_i n_ij
+func Purity(labels, assignments []int) (float64, error) {
+	table, err := NewContingencyTable(labels, assignments)
+	if err != nil {
+		return 0, err
+	}
+
+	majority := make([]int, len(table.ClusterSizes))
+	for _, row := range table.Counts {
+		for j, count := range row {
+			majority[j] = max(majority[j], count)
+		}
+	}
+
+	total := 0
+	for _, count := range majority {
+		total += count
+	}
+
+	return float64(total) / float64(table.N), nil
+}
+
+// pairs returns the number of unordered pairs among n items, C(n, 2).
+func pairs(n int) float64 {
+	return float64(n) * float64(n-1) / 2
+}
+
+// sumPairs returns Σ C(size, 2) over the sizes.
+func sumPairs(sizes []int) float64 {
+	total := 0.0
+	for _, size := range sizes {
+		total += pairs(size)
+	}
+
+	return total
+}
+
+// entropy returns the Shannon entropy (in nats) of a partition of n points with the given group sizes.
+func entropy(sizes []int, n int) float64 {
+	h := 0.0
+	for _, size := range sizes {
+		if size > 0 {
+			p := float64(size) / float64(n)
+			h -= p * math.Log(p)
+		}
+	}
+
+	return h
+}
+
+// mutualInformation returns the mutual information (in nats) of the classes and clusters of the table.
+func mutualInformation(table *ContingencyTable) float64 {
+	n := float64(table.N)
+	mi := 0.0
+	for i, row := range table.Counts {
+		for j, count := range row {
+			if count > 0 {
+				nij := float64(count)
+				mi += nij / n * math.Log(n*nij/(float64(table.ClassSizes[i])*float64(table.ClusterSizes[j])))
+			}
+		}
+	}
+
+	return max(mi, 0) // rounding can make independent partitions slightly negative
+}
+
+// expectedMutualInformation returns the mutual information expected between random partitions
+// with the marginals of the table, summing the hypergeometric distribution of every cell.
+// Factorials are evaluated in log space with math.Lgamma.
+func expectedMutualInformation(table *ContingencyTable) float64 {
+	n := table.N
+	logFactorial := func(x int) float64 {
+		v, _ := math.Lgamma(float64(x) + 1)
+		return v
+	}
+
+	emi := 0.0
+	for _, a := range table.ClassSizes {
+		if a == 0 {
+			continue
+		}
+		for _, b := range table.ClusterSizes {
+			if b == 0 {
+				continue
+			}
+
+			// log(a! b! (n-a)! (n-b)! / n!) does not depend on n_ij
+			base := logFactorial(a) + logFactorial(b) + logFactorial(n-a) + logFactorial(n-b) - logFactorial(n)
+			for nij := max(1, a+b-n); nij <= min(a, b); nij++ {
+				logProbability := base - logFactorial(nij) - logFactorial(a-nij) -
+					logFactorial(b-nij) - logFactorial(n-a-b+nij)
+				term := float64(nij) / float64(n) * math.Log(float64(n)*float64(nij)/(float64(a)*float64(b)))
+				emi += term * math.Exp(logProbability)
+			}
+		}
+	}
+
+	return emi
+}
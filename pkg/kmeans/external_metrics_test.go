@@ -0,0 +1,150 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExternalMetricsSuite struct {
+	suite.Suite
+}
+
+func TestExternalMetricsSuite(t *testing.T) {
+	suite.Run(t, new(ExternalMetricsSuite))
+}
+
+// overlappingLabels returns three classes of three points and a clustering that splits the first class.
+func overlappingLabels() ([]int, []int) {
+	return []int{0, 0, 0, 1, 1, 1, 2, 2, 2}, []int{0, 0, 1, 1, 1, 2, 2, 2, 2}
+}
+
+func (s *ExternalMetricsSuite) TestContingencyTable() {
+	labels, assignments := overlappingLabels()
+
+	table, err := kmeans.NewContingencyTable(labels, assignments)
+	s.Require().NoError(err)
+
+	s.Equal([][]int{{2, 1, 0}, {0, 2, 1}, {0, 0, 3}}, table.Counts)
+	s.Equal([]int{3, 3, 3}, table.ClassSizes)
+	s.Equal([]int{2, 3, 4}, table.ClusterSizes)
+	s.Equal(9, table.N)
+}
+
+func (s *ExternalMetricsSuite) TestReferenceValues() {
+	labels, assignments := overlappingLabels()
+
+	ari, err := kmeans.AdjustedRandIndex(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(0.35714285714285715, ari, 1e-12)
+
+	nmi, err := kmeans.NormalizedMutualInformation(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(0.5895098274473048, nmi, 1e-12)
+
+	ami, err := kmeans.AdjustedMutualInformation(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(0.4086705097217221, ami, 1e-12)
+
+	v, err := kmeans.VMeasure(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(0.579380164285695, v.Homogeneity, 1e-12)
+	s.InDelta(0.6, v.Completeness, 1e-12)
+	s.InDelta(nmi, v.VMeasure, 1e-12) // V-measure equals NMI with the arithmetic mean
+
+	fm, err := kmeans.FowlkesMallows(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(0.5270462766947299, fm, 1e-12)
+
+	purity, err := kmeans.Purity(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(7.0/9.0, purity, 1e-12)
+}
+
+func (s *ExternalMetricsSuite) TestSplitClass() {
+	labels, assignments := []int{0, 0, 1, 1}, []int{0, 0, 1, 2}
+
+	ari, err := kmeans.AdjustedRandIndex(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(4.0/7.0, ari, 1e-12)
+
+	v, err := kmeans.VMeasure(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(1.0, v.Homogeneity, 1e-12)
+	s.InDelta(2.0/3.0, v.Completeness, 1e-12)
+	s.InDelta(0.8, v.VMeasure, 1e-12)
+
+	fm, err := kmeans.FowlkesMallows(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(0.7071067811865475, fm, 1e-12)
+}
+
+func (s *ExternalMetricsSuite) TestIdenticalPartitionsUpToRelabelling() {
+	labels := []int{0, 0, 1, 1, 2, 2}
+	assignments := []int{2, 2, 0, 0, 1, 1}
+
+	for _, score := range []func([]int, []int) (float64, error){
+		kmeans.AdjustedRandIndex,
+		kmeans.NormalizedMutualInformation,
+		kmeans.AdjustedMutualInformation,
+		kmeans.FowlkesMallows,
+		kmeans.Purity,
+	} {
+		value, err := score(labels, assignments)
+		s.Require().NoError(err)
+		s.InDelta(1.0, value, 1e-12)
+	}
+}
+
+func (s *ExternalMetricsSuite) TestTrivialPartitions() {
+	single := []int{0, 0, 0, 0}
+	singletons := []int{0, 1, 2, 3}
+
+	for _, score := range []func([]int, []int) (float64, error){
+		kmeans.AdjustedRandIndex,
+		kmeans.NormalizedMutualInformation,
+		kmeans.AdjustedMutualInformation,
+	} {
+		value, err := score(single, single)
+		s.Require().NoError(err)
+		s.InDelta(1.0, value, 1e-12)
+
+		value, err = score(singletons, singletons)
+		s.Require().NoError(err)
+		s.InDelta(1.0, value, 1e-12)
+
+		value, err = score(single, singletons)
+		s.Require().NoError(err)
+		s.InDelta(0.0, value, 1e-12)
+	}
+
+	fm, err := kmeans.FowlkesMallows(single, singletons)
+	s.Require().NoError(err)
+	s.InDelta(0.0, fm, 1e-12)
+}
+
+func (s *ExternalMetricsSuite) TestScoresKMeansOutput() {
+	points := twoBlobs()
+	labels := []int{1, 1, 1, 0, 0, 0}
+	_, assignments := kmeans.KMeans(points, 2, 10, firstPoints)
+	s.Require().NotNil(assignments)
+
+	ari, err := kmeans.AdjustedRandIndex(labels, assignments)
+	s.Require().NoError(err)
+	s.InDelta(1.0, ari, 1e-12)
+}
+
+func (s *ExternalMetricsSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.NewContingencyTable(nil, nil)
+	s.ErrorIs(err, kmeans.ErrNoPoints)
+
+	_, err = kmeans.AdjustedRandIndex([]int{0, 1}, []int{0})
+	s.ErrorIs(err, kmeans.ErrLabelsMismatch)
+
+	_, err = kmeans.VMeasure([]int{0, -1}, []int{0, 1})
+	s.ErrorIs(err, kmeans.ErrInvalidLabel)
+
+	_, err = kmeans.Purity([]int{0, 1}, []int{0, -1})
+	s.ErrorIs(err, kmeans.ErrInvalidAssignment)
+}
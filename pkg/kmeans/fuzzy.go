@@ -0,0 +1,212 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// FuzzyResult describes the outcome of a fuzzy c-means clustering.
+type FuzzyResult struct {
+	Centers []Point // final positions of the cluster centers
+	// Memberships is the n×k membership matrix: Memberships[i][j] is the degree in [0, 1]
+	// to which point i belongs to cluster j. Every row sums to 1.
+	Memberships [][]float64
+	Objective   float64 // weighted within-cluster error Σ_i Σ_j u_ij^m · d(x_i, c_j)²
+	Iterations  int     // number of membership/center updates actually run
+	Converged   bool    // true if no membership changed by more than epsilon before the iteration cap
+}
+
+// FuzzyCMeans performs fuzzy c-means clustering (Bezdek): every point belongs to every cluster
+// with a degree of membership, instead of to a single one. Memberships and centers are updated
+// alternately until no membership changes by more than the epsilon set with WithEpsilon.
+//
+// Formula:
+//   - membership: u_ij = 1 / Σ_l (d(x_i, c_j) / d(x_i, c_l))^(2/(m-1))
+//   - center: c_j = Σ_i u_ij^m · x_i / Σ_i u_ij^m
+//
+// A point that coincides with one or more centers is shared equally between them.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - opts: WithK (required), WithFuzzifier, WithEpsilon, WithMaxIterations, the initializer of the
+//     starting centers (k-means++ by default), WithSeed or WithRand, WithMetric and WithWorkers.
+//     The metric must have the mean as its optimal centroid. The other options of Fit do not apply.
+//
+// Returns:
+//   - result: centers, the membership matrix and statistics of the run
+//   - err: one of the Err* validation errors if the input or options are invalid
+func FuzzyCMeans(points []Point, opts ...Option) (*FuzzyResult, error) {
+	cfg := newConfig(opts)
+	if err := cfg.validateBase(points); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateFuzzy(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.rejectWeights("fuzzy c-means"); err != nil {
+		return nil, err
+	}
+
+	if !cfg.metric.MeanCentroid() {
+		return nil, fmt.Errorf("%w: %s", ErrMetricNotMeanCompatible, metricName(cfg.metric))
+	}
+
+	centers := clonePoints(cfg.initializer(points, cfg.k, cfg.initConfig()))
+	memberships := make([][]float64, len(points))
+	for i := range memberships {
+		memberships[i] = make([]float64, cfg.k)
+	}
+
+	result := &FuzzyResult{Centers: centers, Memberships: memberships}
+	for result.Iterations < cfg.maxIterations {
+		// The first update always counts as a change, there are no previous memberships
+		changed := updateMemberships(points, centers, memberships, &cfg) || result.Iterations == 0
+		updateCenters(points, centers, memberships, &cfg)
+		result.Iterations++
+
+		if !changed {
+			result.Converged = true
+			break
+		}
+	}
+
+	for i, p := range points {
+		for j, c := range centers {
+			result.Objective += math.Pow(memberships[i][j], cfg.fuzzifier) * squaredError(cfg.metric, p, c)
+		}
+	}
+
+	return result, nil
+}
+
+// updateMemberships recomputes the memberships of every point from its distances to the centers.
+// It reports whether any membership changed by more than cfg.epsilon.
+func updateMemberships(points, centers []Point, memberships [][]float64, cfg *config) bool {
+	exponent := 1 / (cfg.fuzzifier - 1) // on squared distances, i.e. 2/(m-1) on distances
+	changed, _ := parallelPass(context.Background(), len(points), cfg.workers, func(i int) bool {
+		distances := make([]float64, len(centers))
+		coinciding := 0
+		for j, c := range centers {
+			distances[j] = squaredError(cfg.metric, points[i], c)
+			if distances[j] == 0 {
+				coinciding++
+			}
+		}
+
+		largest := 0.0
+		for j, dj := range distances {
+			u := 0.0
+			switch {
+			case coinciding > 0 && dj == 0:
+				u = 1 / float64(coinciding)
+			case coinciding == 0:
+				sum := 0.0
+				for _, dl := range distances {
+					sum += math.Pow(dj/dl, exponent)
+				}
+				u = 1 / sum
+			}
+
+			largest = math.Max(largest, math.Abs(u-memberships[i][j]))
+			memberships[i][j] = u
+		}
+
+		return largest > cfg.epsilon
+	})
+
+	return changed
+}
+
+// updateCenters moves every center to the mean of all points weighted by u_ij^m.
+// A center with no weight at all keeps its previous position.
+//
+// The work is split by dimension, so the result does not depend on the number of workers.
+func updateCenters(points, centers []Point, memberships [][]float64, cfg *config) {
+	weights := make([][]float64, len(points))
+	totals := make([]float64, len(centers))
+	for i, row := range memberships {
+		weights[i] = make([]float64, len(row))
+		for j, u := range row {
+			weights[i][j] = math.Pow(u, cfg.fuzzifier)
+			totals[j] += weights[i][j]
+		}
+	}
+
+	dim := len(points[0])
+	sums := make([]Point, len(centers))
+	for j := range sums {
+		sums[j] = make(Point, dim)
+	}
+
+	spans := splitRange(dim, effectiveWorkers(len(points), cfg.workers))
+	runSpans(spans, func(_ int, s span) {
+		for i, p := range points {
+			for j, w := range weights[i] {
+				for d := s.lo; d < s.hi; d++ {
+					sums[j][d] += w * p[d]
+				}
+			}
+		}
+	})
+
+	for j, sum := range sums {
+		if totals[j] == 0 {
+			continue
+		}
+
+		for d := range sum {
+			sum[d] /= totals[j]
+		}
+		centers[j] = sum
+	}
+}
+
+// Harden turns the memberships into hard assignments: every point goes to the cluster
+// with its largest membership. Ties go to the cluster with the lowest index.
+func (r *FuzzyResult) Harden() []int {
+	assignments := make([]int, len(r.Memberships))
+	for i, row := range r.Memberships {
+		for j, u := range row {
+			if u > row[assignments[i]] {
+				assignments[i] = j
+			}
+		}
+	}
+
+	return assignments
+}
+
+// PartitionCoefficient returns Bezdek's partition coefficient of the memberships, between 1/k
+// for a completely fuzzy partition (all memberships 1/k) and 1 for a hard partition. Higher is crisper.
+//
+// Formula: PC = (1/n) · Σ_i Σ_j u_ij²
+func (r *FuzzyResult) PartitionCoefficient() float64 {
+	sum := 0.0
+	for _, row := range r.Memberships {
+		for _, u := range row {
+			sum += u * u
+		}
+	}
+
+	return sum / float64(len(r.Memberships))
+}
+
+// PartitionEntropy returns the partition entropy of the memberships, between 0 for a hard
+// partition and ln(k) for a completely fuzzy one. Lower is crisper.
+//
+// Formula: PE = -(1/n) · Σ_i Σ_j u_ij · ln(u_ij), with 0 · ln(0) = 0
+func (r *FuzzyResult) PartitionEntropy() float64 {
+	sum := 0.0
+	for _, row := range r.Memberships {
+		for _, u := range row {
+			if u > 0 {
+				sum -= u * math.Log(u)
+			}
+		}
+	}
+
+	return sum / float64(len(r.Memberships))
+}
@@ -0,0 +1,133 @@
+package kmeans_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type FuzzyCMeansSuite struct {
+	suite.Suite
+}
+
+func TestFuzzyCMeansSuite(t *testing.T) {
+	suite.Run(t, new(FuzzyCMeansSuite))
+}
+
+// symmetricPair returns two mirrored groups on a line and a point halfway between them.
+func symmetricPair() []kmeans.Point {
+	return []kmeans.Point{{-11, 0}, {-10, 0}, {-9, 0}, {9, 0}, {10, 0}, {11, 0}, {0, 0}}
+}
+
+// mirroredStart starts the centers symmetrically, so they stay mirrored.
+func mirroredStart(_ []kmeans.Point, _ int) []kmeans.Point {
+	return []kmeans.Point{{-1, 0}, {1, 0}}
+}
+
+func (s *FuzzyCMeansSuite) TestMembershipsOfSymmetricPair() {
+	result, err := kmeans.FuzzyCMeans(symmetricPair(), kmeans.WithK(2), kmeans.WithInitializer(mirroredStart))
+	s.Require().NoError(err)
+
+	s.True(result.Converged)
+	s.Len(result.Memberships, 7)
+	for _, row := range result.Memberships {
+		s.Len(row, 2)
+		s.InDelta(1.0, row[0]+row[1], 1e-12)
+	}
+
+	s.InDelta(0.5, result.Memberships[6][0], 1e-9) // halfway between both centers
+	s.Greater(result.Memberships[1][0], 0.99)
+	s.Greater(result.Memberships[4][1], 0.99)
+	s.InDelta(-result.Centers[0][0], result.Centers[1][0], 1e-9)
+	s.Equal([]int{0, 0, 0, 1, 1, 1, 0}, result.Harden()) // the tie goes to the lowest index
+}
+
+func (s *FuzzyCMeansSuite) TestObjectiveMatchesMemberships() {
+	points := fourBlobs()
+	result, err := kmeans.FuzzyCMeans(points, kmeans.WithK(4), kmeans.WithSeed(1), kmeans.WithFuzzifier(1.5))
+	s.Require().NoError(err)
+
+	objective := 0.0
+	for i, p := range points {
+		for j, c := range result.Centers {
+			d := kmeans.Euclidean.Distance(p, c)
+			objective += math.Pow(result.Memberships[i][j], 1.5) * d * d
+		}
+	}
+	s.InDelta(objective, result.Objective, 1e-6)
+	s.Equal([]int{80, 80, 80, 80}, sortedSizes(hardSizes(result.Harden(), 4)))
+}
+
+// hardSizes counts the points of every cluster of hard assignments.
+func hardSizes(assignments []int, k int) []int {
+	sizes := make([]int, k)
+	for _, a := range assignments {
+		sizes[a]++
+	}
+	return sizes
+}
+
+func (s *FuzzyCMeansSuite) TestValidityIndices() {
+	points := fourBlobs()
+
+	crisp, err := kmeans.FuzzyCMeans(points, kmeans.WithK(4), kmeans.WithSeed(2), kmeans.WithFuzzifier(1.2))
+	s.Require().NoError(err)
+	fuzzy, err := kmeans.FuzzyCMeans(points, kmeans.WithK(4), kmeans.WithSeed(2), kmeans.WithFuzzifier(4))
+	s.Require().NoError(err)
+
+	s.Greater(crisp.PartitionCoefficient(), fuzzy.PartitionCoefficient())
+	s.Less(crisp.PartitionEntropy(), fuzzy.PartitionEntropy())
+	s.GreaterOrEqual(fuzzy.PartitionCoefficient(), 0.25)
+	s.LessOrEqual(fuzzy.PartitionEntropy(), math.Log(4))
+}
+
+func (s *FuzzyCMeansSuite) TestEveryPointIsACenter() {
+	points := []kmeans.Point{{0, 0}, {5, 5}, {10, 0}}
+	result, err := kmeans.FuzzyCMeans(points, kmeans.WithK(3), kmeans.WithSeed(3))
+	s.Require().NoError(err)
+
+	s.InDelta(1.0, result.PartitionCoefficient(), 1e-12)
+	s.InDelta(0.0, result.PartitionEntropy(), 1e-12)
+	s.InDelta(0.0, result.Objective, 1e-12)
+	s.ElementsMatch([]int{0, 1, 2}, result.Harden())
+}
+
+func (s *FuzzyCMeansSuite) TestIterationCap() {
+	result, err := kmeans.FuzzyCMeans(fourBlobs(), kmeans.WithK(4), kmeans.WithSeed(4),
+		kmeans.WithMaxIterations(1), kmeans.WithEpsilon(0))
+	s.Require().NoError(err)
+
+	s.Equal(1, result.Iterations)
+	s.False(result.Converged)
+}
+
+func (s *FuzzyCMeansSuite) TestIndependentOfWorkers() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {6, 6}, {0, 6}}, 1500, 1.5, 9)
+
+	serial, err := kmeans.FuzzyCMeans(points, kmeans.WithK(3), kmeans.WithSeed(5), kmeans.WithWorkers(1))
+	s.Require().NoError(err)
+	parallel, err := kmeans.FuzzyCMeans(points, kmeans.WithK(3), kmeans.WithSeed(5), kmeans.WithWorkers(2))
+	s.Require().NoError(err)
+
+	s.Equal(serial.Centers, parallel.Centers)
+	s.Equal(serial.Memberships, parallel.Memberships)
+}
+
+func (s *FuzzyCMeansSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.FuzzyCMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithFuzzifier(1))
+	s.ErrorIs(err, kmeans.ErrInvalidFuzzifier)
+
+	_, err = kmeans.FuzzyCMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithFuzzifier(math.NaN()))
+	s.ErrorIs(err, kmeans.ErrInvalidFuzzifier)
+
+	_, err = kmeans.FuzzyCMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithEpsilon(-1))
+	s.ErrorIs(err, kmeans.ErrInvalidTolerance)
+
+	_, err = kmeans.FuzzyCMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithMetric(kmeans.Manhattan))
+	s.ErrorIs(err, kmeans.ErrMetricNotMeanCompatible)
+
+	_, err = kmeans.FuzzyCMeans(twoBlobs(), kmeans.WithK(7))
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+}
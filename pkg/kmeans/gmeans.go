@@ -0,0 +1,281 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+const (
+	// minGaussianTestSize is the smallest cluster G-means tests; smaller clusters are never split.
+	minGaussianTestSize = 8
+	// powerIterations caps the power iteration computing the principal direction of a cluster.
+	powerIterations = 100
+	// powerTolerance stops the power iteration once the direction changes less than this.
+	powerTolerance = 1e-10
+	// andersonDarlingLinear and andersonDarlingQuadratic correct A² for the estimated mean and variance.
+	andersonDarlingLinear    = 0.75
+	andersonDarlingQuadratic = 2.25
+	// andersonDarlingMaxStatistic is the largest A*² the p-value approximation was fitted for, as
+	// in statsmodels; above it, and for an infinite statistic that would give NaN, p is taken as 0.
+	andersonDarlingMaxStatistic = 13
+)
+
+// andersonDarlingTable approximates the p-value of the adjusted statistic A*² piecewise
+// (D'Agostino and Stephens, "Goodness-of-Fit Techniques", table 4.9). From its lower bound up,
+// every piece gives p = exp(c0 + c1·A + c2·A²), or 1 minus that for the complement pieces.
+var andersonDarlingTable = []struct {
+	from       float64
+	c0, c1, c2 float64
+	complement bool
+}{
+	{0.6, 1.2937, -5.709, 0.0186, false},
+	{0.34, 0.9177, -4.279, -1.38, false},
+	{0.2, -8.318, 42.796, -59.938, true},
+	{math.Inf(-1), -13.436, 101.14, -223.73, true},
+}
+
+// GMeansResult describes the outcome of GMeans.
+type GMeansResult struct {
+	K           int     // final number of clusters
+	Centroids   []Point // final positions of the cluster centroids
+	Assignments []int   // index of the centroid assigned to each point
+	Ks          []int   // number of clusters of the run over all points in every round
+	Result      *Result // final Lloyd run over all points, with its SSE, sizes and stop reason
+}
+
+// GMeans clusters the points with G-means (Hamerly and Elkan), which grows k until every cluster looks Gaussian.
+//
+// It runs k-means with kMin clusters seeded by the configured initializer. Then, for every cluster,
+// it runs a local 2-means started at c ± sqrt(2λ/π)·s, where s is the principal direction of the
+// cluster and λ its variance, projects the points onto the line joining the two children and applies
+// an Anderson–Darling normality test to the projection. Clusters failing the test at the significance
+// level of WithSignificance are replaced by their children, the most significant first if kMax
+// does not allow all of them, and the next round runs over all points. It stops once every cluster
+// passes or kMax is reached. Clusters with fewer than 8 points are not tested.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - kMin, kMax: range of the number of clusters, 1 <= kMin <= kMax <= n
+//   - opts: options of every Fit run; WithK and the initializer of the later runs are ignored
+//
+// Returns:
+//   - result: final k, centroids, assignments and the number of clusters of every round
+//   - err: one of the Err* validation errors, or the first error of a Fit run
+func GMeans(points []Point, kMin, kMax int, opts ...Option) (*GMeansResult, error) {
+	if kMin < 1 || kMax < kMin {
+		return nil, fmt.Errorf("%w: [%d, %d]", ErrInvalidKRange, kMin, kMax)
+	}
+
+	cfg := newConfig(append(slices.Clone(opts), WithK(kMax)))
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateSignificance(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.rejectWeights("G-means"); err != nil {
+		return nil, err
+	}
+
+	g := &splitter{opts: opts, rng: cfg.rng}
+	result, err := g.fit(points, kMin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := []int{kMin}
+	for len(result.Centroids) < kMax {
+		centroids, err := g.splitByGaussianity(points, result, kMax, cfg.significance)
+		if err != nil {
+			return nil, err
+		}
+		if len(centroids) == len(result.Centroids) {
+			break // every cluster looks Gaussian
+		}
+
+		if result, err = g.fit(points, len(centroids), centroids); err != nil {
+			return nil, err
+		}
+		ks = append(ks, len(centroids))
+	}
+
+	return &GMeansResult{
+		K:           len(result.Centroids),
+		Centroids:   result.Centroids,
+		Assignments: result.Assignments,
+		Ks:          ks,
+		Result:      result,
+	}, nil
+}
+
+// splitByGaussianity tests every cluster of the result and returns the centroids of the next run,
+// splitting the clusters whose projection is not Gaussian at the given significance level.
+func (x *splitter) splitByGaussianity(points []Point, result *Result, kMax int, significance float64) ([]Point, error) {
+	var candidates []splitCandidate
+	for j, cluster := range clusterMembers(points, result.Assignments, len(result.Centroids)) {
+		if len(cluster) < minGaussianTestSize {
+			continue
+		}
+
+		center := result.Centroids[j]
+		direction, variance := principalDirection(cluster, center)
+		if variance == 0 {
+			continue // all points coincide
+		}
+
+		offset := math.Sqrt(2 * variance / math.Pi)
+		start := []Point{make(Point, len(center)), make(Point, len(center))}
+		for d := range center {
+			start[0][d] = center[d] + offset*direction[d]
+			start[1][d] = center[d] - offset*direction[d]
+		}
+
+		children, err := x.fit(cluster, 2, start)
+		if err != nil {
+			return nil, fmt.Errorf("splitting cluster %d: %w", j, err)
+		}
+
+		statistic, pValue := andersonDarling(project(cluster, children.Centroids[0], children.Centroids[1]))
+		if pValue < significance {
+			candidates = append(candidates, splitCandidate{parent: j, children: children.Centroids, gain: statistic})
+		}
+	}
+
+	return applySplits(result.Centroids, candidates, kMax), nil
+}
+
+// principalDirection returns the unit eigenvector of the largest eigenvalue of the covariance
+// of the points around center, and that eigenvalue, using power iteration.
+// The iteration starts from the point farthest from the center.
+func principalDirection(points []Point, center Point) (Point, float64) {
+	direction := make(Point, len(center))
+	farthest := 0.0
+	for _, p := range points {
+		if d := distance(p, center); d > farthest {
+			farthest = d
+			for i := range direction {
+				direction[i] = (p[i] - center[i]) / d
+			}
+		}
+	}
+	if farthest == 0 {
+		return direction, 0
+	}
+
+	variance := 0.0
+	for range powerIterations {
+		// next = Cov · direction = (1/n) Σ (x - c) · <x - c, direction>
+		next := make(Point, len(center))
+		for _, p := range points {
+			dot := 0.0
+			for i := range p {
+				dot += (p[i] - center[i]) * direction[i]
+			}
+			for i := range p {
+				next[i] += (p[i] - center[i]) * dot / float64(len(points))
+			}
+		}
+
+		variance = distance(next, make(Point, len(next)))
+		if variance == 0 {
+			return direction, 0
+		}
+		for i := range next {
+			next[i] /= variance
+		}
+
+		converged := distance(next, direction) < powerTolerance
+		direction = next
+		if converged {
+			break
+		}
+	}
+
+	return direction, variance
+}
+
+// project maps every point onto the line through a and b: x' = <x, a - b> / ||a - b||².
+func project(points []Point, a, b Point) []float64 {
+	axis := make(Point, len(a))
+	norm := 0.0
+	for i := range a {
+		axis[i] = a[i] - b[i]
+		norm += axis[i] * axis[i]
+	}
+
+	values := make([]float64, len(points))
+	for n, p := range points {
+		for i := range p {
+			values[n] += p[i] * axis[i]
+		}
+		if norm > 0 {
+			values[n] /= norm
+		}
+	}
+
+	return values
+}
+
+// andersonDarling tests whether the values come from a normal distribution with unknown mean and variance.
+// It returns the adjusted statistic A*² and its p-value, using the approximations of D'Agostino and Stephens.
+// Values without any spread are reported as normal.
+//
+// Formula:
+//
+//	z_(i) = sorted standardized values, F = standard normal CDF
+//	A²    = -n - (1/n) Σ_i (2i - 1) [ln F(z_(i)) + ln(1 - F(z_(n+1-i)))]
+//	A*²   = A² (1 + 0.75/n + 2.25/n²)
+func andersonDarling(values []float64) (float64, float64) {
+	n := float64(len(values))
+	mean := sum(values) / n
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(variance / (n - 1))
+	if std == 0 {
+		return 0, 1
+	}
+
+	// Keep the CDF away from 0 and 1, where Erfc underflows, so both logarithms stay finite
+	cdf := make([]float64, len(values))
+	for i, v := range values {
+		cdf[i] = min(max(0.5*math.Erfc(-(v-mean)/std/math.Sqrt2), math.SmallestNonzeroFloat64), math.Nextafter(1, 0))
+	}
+	slices.Sort(cdf)
+
+	total := 0.0
+	for i := range cdf {
+		total += float64(2*i+1) * (math.Log(cdf[i]) + math.Log(1-cdf[len(cdf)-1-i]))
+	}
+	statistic := (-n - total/n) * (1 + andersonDarlingLinear/n + andersonDarlingQuadratic/(n*n))
+
+	return statistic, andersonDarlingPValue(statistic)
+}
+
+// andersonDarlingPValue approximates the p-value of the adjusted statistic A*² of a normality test
+// with estimated mean and variance. The table only extends to A*² = 13, so larger statistics,
+// including +Inf, get p = 0 instead of an extrapolated (or NaN) value, following statsmodels.
+func andersonDarlingPValue(a float64) float64 {
+	if a > andersonDarlingMaxStatistic {
+		return 0
+	}
+
+	for _, piece := range andersonDarlingTable {
+		if a < piece.from {
+			continue
+		}
+
+		p := math.Exp(piece.c0 + piece.c1*a + piece.c2*a*a)
+		if piece.complement {
+			p = 1 - p
+		}
+
+		return min(max(p, 0), 1)
+	}
+
+	return 1 // NaN statistic
+}
@@ -0,0 +1,113 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type GMeansSuite struct {
+	suite.Suite
+}
+
+func TestGMeansSuite(t *testing.T) {
+	suite.Run(t, new(GMeansSuite))
+}
+
+func (s *GMeansSuite) TestFindsFourBlobs() {
+	points := fourBlobs()
+
+	result, err := kmeans.GMeans(points, 1, 20, kmeans.WithSeed(3))
+	s.Require().NoError(err)
+
+	s.Equal(4, result.K)
+	s.Len(result.Centroids, 4)
+	s.Len(result.Assignments, len(points))
+	s.Equal([]int{80, 80, 80, 80}, result.Result.ClusterSizes)
+	s.Equal(1, result.Ks[0])
+	s.Equal(4, result.Ks[len(result.Ks)-1])
+}
+
+func (s *GMeansSuite) TestFindsSymmetricBlobs() {
+	// Unlike the BIC of X-means, the projection of a square of blobs is clearly bimodal
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {12, 0}, {0, 12}, {12, 12}}, 80, 1, 8)
+
+	result, err := kmeans.GMeans(points, 1, 20, kmeans.WithSeed(4))
+	s.Require().NoError(err)
+
+	s.Equal(4, result.K)
+}
+
+func (s *GMeansSuite) TestSplitsLargeClearlySeparatedBlobs() {
+	// Thousands of points push A*² far beyond the range of the p-value approximation
+	cases := []struct {
+		gap       float64
+		perCenter int
+	}{
+		{100, 1000},
+		{100, 3000},
+		{10, 5000},
+	}
+	for _, c := range cases {
+		points := gaussianBlobs([]kmeans.Point{{0, 0}, {c.gap, 0}}, c.perCenter, 1, 13)
+
+		result, err := kmeans.GMeans(points, 1, 10, kmeans.WithSeed(5))
+		s.Require().NoError(err)
+
+		s.Equal(2, result.K, "gap %g, %d points per blob", c.gap, c.perCenter)
+	}
+}
+
+func (s *GMeansSuite) TestSingleGaussianIsNotSplit() {
+	points := gaussianBlobs([]kmeans.Point{{5, 5, 5}}, 500, 2, 12)
+
+	result, err := kmeans.GMeans(points, 1, 10, kmeans.WithSeed(1))
+	s.Require().NoError(err)
+
+	s.Equal(1, result.K)
+	s.Equal([]int{1}, result.Ks)
+}
+
+func (s *GMeansSuite) TestUniformDataIsSplit() {
+	points := line(400)
+
+	result, err := kmeans.GMeans(points, 1, 50, kmeans.WithSeed(2))
+	s.Require().NoError(err)
+
+	s.Greater(result.K, 1)
+}
+
+func (s *GMeansSuite) TestStopsAtKMax() {
+	result, err := kmeans.GMeans(fourBlobs(), 1, 3, kmeans.WithSeed(2))
+	s.Require().NoError(err)
+
+	s.Equal(3, result.K)
+}
+
+func (s *GMeansSuite) TestReproducibleWithSeed() {
+	points := fourBlobs()
+
+	first, err := kmeans.GMeans(points, 1, 20, kmeans.WithSeed(6))
+	s.Require().NoError(err)
+	second, err := kmeans.GMeans(points, 1, 20, kmeans.WithSeed(6))
+	s.Require().NoError(err)
+
+	s.Equal(first.Centroids, second.Centroids)
+	s.Equal(first.Assignments, second.Assignments)
+	s.Equal(first.Ks, second.Ks)
+}
+
+func (s *GMeansSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.GMeans(fourBlobs(), 2, 1)
+	s.ErrorIs(err, kmeans.ErrInvalidKRange)
+
+	_, err = kmeans.GMeans(fourBlobs(), 1, 4, kmeans.WithSignificance(0))
+	s.ErrorIs(err, kmeans.ErrInvalidSignificance)
+
+	_, err = kmeans.GMeans(fourBlobs(), 1, 4, kmeans.WithSignificance(1))
+	s.ErrorIs(err, kmeans.ErrInvalidSignificance)
+
+	_, err = kmeans.GMeans(twoBlobs(), 1, 7)
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+}
@@ -1,16 +1,78 @@
 package kmeans
 
 import (
+	"context"
 	"math"
 	"math/rand"
+	"runtime"
 )
 
+const (
+	// parallelPlusPlusRounds is the number of oversampling rounds of k-means||.
+	// Bahmani et al. report that 5 rounds are enough in practice.
+	parallelPlusPlusRounds = 5
+	// parallelPlusPlusOversampling is the expected number of candidates per round, as a multiple of k.
+	parallelPlusPlusOversampling = 2
+)
+
+// InitConfig carries the per-run state a SeededInitializer may draw on.
+type InitConfig struct {
+	Rand    *rand.Rand // source of randomness; a nil value falls back to the global math/rand source
+	Metric  Metric     // distance used to spread the centroids; a nil value means Euclidean
+	Workers int        // goroutines available to the initializer; 0 means GOMAXPROCS
+	Weights []float64  // sample weight of every point, see WithWeights; nil means unit weights
+}
+
+// metric returns the configured metric, or Euclidean if none is set.
+func (c InitConfig) metric() Metric {
+	if c.Metric != nil {
+		return c.Metric
+	}
+
+	return Euclidean
+}
+
+// workers returns the configured number of goroutines, or GOMAXPROCS if none is set.
+func (c InitConfig) workers() int {
+	if c.Workers > 0 {
+		return c.Workers
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// SeededInitializer picks k initial centroids using the randomness (and other settings) of the run.
+// Unlike InitializeCentroidsFunction it gives reproducible results for a fixed seed.
+type SeededInitializer func(points []Point, k int, cfg InitConfig) []Point
+
+// random returns the configured source of randomness, or an unseeded one if none is set.
+func (c InitConfig) random() *rand.Rand {
+	if c.Rand != nil {
+		return c.Rand
+	}
+
+	return unseededRand()
+}
+
+// unseededRand returns a new generator seeded from the global math/rand source,
+// for runs that do not ask for reproducibility.
+func unseededRand() *rand.Rand {
+	// #nosec G404 -- Non-reproducible run, seeded from the global source
+	return rand.New(rand.NewSource(rand.Int63()))
+}
+
 // randomCentroids selects k random points from the dataset to serve as initial centroids.
 // It randomly permutes the indices and picks the first k points.
 func RandomCentroids(points []Point, k int) []Point {
+	return SeededRandomCentroids(points, k, InitConfig{})
+}
+
+// SeededRandomCentroids works like RandomCentroids but draws the permutation from cfg.Rand,
+// so a fixed seed always selects the same points.
+func SeededRandomCentroids(points []Point, k int, cfg InitConfig) []Point {
 	centroids := make([]Point, k)
 	// #nosec G404 -- Random permutation of indices
-	perm := rand.Perm(len(points))
+	perm := cfg.random().Perm(len(points))
 
 	for i := 0; i < k; i++ {
 		centroids[i] = points[perm[i]] // Randomly chosen points
@@ -21,14 +83,30 @@ func RandomCentroids(points []Point, k int) []Point {
 
 // smartCentroids initializes centroids using the k-means++ method.
 // It selects centroids that are spread out across the data to improve clustering performance.
+// SeededSmartCentroids additionally takes sample weights into account.
 func SmartCentroids(points []Point, k int) []Point {
+	return SeededSmartCentroids(points, k, InitConfig{})
+}
+
+// SeededSmartCentroids works like SmartCentroids but draws every random choice from cfg.Rand,
+// so a fixed seed always selects the same points. Distances are measured with cfg.Metric.
+// With cfg.Weights the first centroid is picked proportionally to weight and the next ones
+// proportionally to weight × D², so a point counts like as many copies as its weight.
+func SeededSmartCentroids(points []Point, k int, cfg InitConfig) []Point {
 	nPoints := len(points)
+	rng := cfg.random()
+	metric := cfg.metric()
 
 	// Initialize centroids slice
 	centroids := make([]Point, 0, k)
 
 	// #nosec G404 -- Step 1: Randomly pick the first centroid
-	firstIndex := rand.Intn(nPoints)
+	var firstIndex int
+	if cfg.Weights != nil {
+		firstIndex = sampleProportional(rng, cfg.Weights)
+	} else {
+		firstIndex = rng.Intn(nPoints)
+	}
 	centroids = append(centroids, points[firstIndex])
 
 	// Step 2: Select the remaining k-1 centroids
@@ -38,20 +116,19 @@ func SmartCentroids(points []Point, k int) []Point {
 
 		// For each point, calculate the squared distance to the nearest existing centroid
 		for i, point := range points {
-			minDistance := math.MaxFloat64
+			dSquared := math.MaxFloat64
 			for _, centroid := range centroids {
-				d := distance(point, centroid)
-				if d < minDistance {
-					minDistance = d
+				d := squaredError(metric, point, centroid)
+				if d < dSquared {
+					dSquared = d
 				}
 			}
-			dSquared := math.Pow(minDistance, 2)
-			distances[i] = dSquared
-			total += dSquared // total must be the sum of squared distances!
+			distances[i] = weightOf(cfg.Weights, i) * dSquared
+			total += distances[i] // total must be the sum of (weighted) squared distances!
 		}
 
 		// #nosec G404 -- Pick a new point with probability proportional to distance squared
-		randomPoint := rand.Float64() * total
+		randomPoint := rng.Float64() * total
 		cumulative := 0.0
 
 		for i, d := range distances {
@@ -65,3 +142,211 @@ func SmartCentroids(points []Point, k int) []Point {
 
 	return centroids
 }
+
+// ParallelPlusPlusCentroids initializes centroids using k-means|| (Bahmani et al., "Scalable K-Means++").
+// Instead of k sequential passes over the data it runs a few oversampling rounds, each picking
+// about 2k points independently with probability proportional to their squared distance to the
+// candidates chosen so far. The candidates are then weighted by the number of points closest
+// to them and reduced to k centroids with weighted k-means++.
+func ParallelPlusPlusCentroids(points []Point, k int) []Point {
+	return SeededParallelPlusPlusCentroids(points, k, InitConfig{})
+}
+
+// SeededParallelPlusPlusCentroids works like ParallelPlusPlusCentroids but draws every random
+// choice from cfg.Rand, measures distances with cfg.Metric and spreads the distance
+// computations over cfg.Workers goroutines. With cfg.Weights every cost is multiplied by the
+// weight of the point and the candidates are weighted by the total weight of their points.
+func SeededParallelPlusPlusCentroids(points []Point, k int, cfg InitConfig) []Point {
+	rng, metric, workers, weights := cfg.random(), cfg.metric(), cfg.workers(), cfg.Weights
+	oversampling := float64(parallelPlusPlusOversampling * k)
+
+	// Weighted squared distance of every point to its nearest candidate
+	costs := make([]float64, len(points))
+	for i := range costs {
+		costs[i] = math.Inf(1)
+	}
+
+	// #nosec G404 -- Step 1: Randomly pick the first candidate
+	var first int
+	if weights != nil {
+		first = sampleProportional(rng, weights)
+	} else {
+		first = rng.Intn(len(points))
+	}
+	candidates := []int{first}
+	updateCosts(points, costs, candidates, metric, weights, workers)
+
+	// Step 2: Oversample about 2k candidates per round, each point independently
+	for range parallelPlusPlusRounds {
+		total := sum(costs)
+		if total == 0 {
+			break // every point already is a candidate
+		}
+
+		var added []int
+		for i, cost := range costs {
+			// #nosec G404 -- Keep the point with probability l * d^2 / total
+			if rng.Float64()*total < oversampling*cost {
+				added = append(added, i)
+			}
+		}
+
+		candidates = append(candidates, added...)
+		updateCosts(points, costs, added, metric, weights, workers)
+	}
+
+	// Step 3: Make sure there are at least k candidates to choose from
+	for len(candidates) < k {
+		next := sampleProportional(rng, costs)
+		if next < 0 {
+			next = randomNonCandidate(rng, len(points), candidates)
+		}
+		candidates = append(candidates, next)
+		updateCosts(points, costs, candidates[len(candidates)-1:], metric, weights, workers)
+	}
+
+	// Step 4: Weight the candidates by their cluster sizes and reduce them to k centroids
+	candidatePoints := make([]Point, len(candidates))
+	for i, index := range candidates {
+		candidatePoints[i] = points[index]
+	}
+	candidateTotals := candidateWeights(points, candidatePoints, metric, weights, workers)
+
+	return weightedPlusPlus(candidatePoints, candidateTotals, k, rng, metric)
+}
+
+// updateCosts lowers the cost of every point to its weighted squared distance to the nearest of the new candidates.
+func updateCosts(points []Point, costs []float64, candidates []int, metric Metric, weights []float64, workers int) {
+	_, _ = parallelPass(context.Background(), len(points), workers, func(i int) bool {
+		for _, c := range candidates {
+			costs[i] = min(costs[i], weightOf(weights, i)*squaredError(metric, points[i], points[c]))
+		}
+
+		return false
+	})
+}
+
+// candidateWeights adds up the weights of the points closest to each candidate,
+// i.e. counts them without sample weights.
+func candidateWeights(points, candidates []Point, metric Metric, weights []float64, workers int) []float64 {
+	nearest := make([]int, len(points))
+	_, _ = parallelPass(context.Background(), len(points), workers, func(i int) bool {
+		nearest[i] = nearestCentroid(points[i], candidates, metric)
+		return false
+	})
+
+	totals := make([]float64, len(candidates))
+	for i, c := range nearest {
+		totals[c] += weightOf(weights, i)
+	}
+
+	return totals
+}
+
+// weightedPlusPlus picks k of the points with k-means++ where every point counts weights[i] times:
+// the first one with probability proportional to its weight, the next ones proportional to
+// weight * D^2. Every point is picked at most once, so len(points) must be at least k.
+func weightedPlusPlus(points []Point, weights []float64, k int, rng *rand.Rand, metric Metric) []Point {
+	chosen := make([]bool, len(points))
+	centroids := make([]Point, 0, k)
+	pick := func(i int) {
+		chosen[i] = true
+		centroids = append(centroids, points[i])
+	}
+
+	first := sampleProportional(rng, weights)
+	if first < 0 {
+		first = rng.Intn(len(points))
+	}
+	pick(first)
+
+	distances := make([]float64, len(points))
+	for i := range distances {
+		distances[i] = math.Inf(1)
+	}
+	scores := make([]float64, len(points))
+
+	for len(centroids) < k {
+		last := centroids[len(centroids)-1]
+		for i, point := range points {
+			distances[i] = min(distances[i], squaredError(metric, point, last))
+			scores[i] = 0
+			if !chosen[i] {
+				scores[i] = weights[i] * distances[i]
+			}
+		}
+
+		next := sampleProportional(rng, scores)
+		if next < 0 {
+			next = firstNotChosen(chosen) // all remaining points coincide with centroids
+		}
+		pick(next)
+	}
+
+	return centroids
+}
+
+// sampleProportional picks an index with probability proportional to its weight.
+// It returns -1 if all weights are zero.
+func sampleProportional(rng *rand.Rand, weights []float64) int {
+	total := sum(weights)
+	if total <= 0 {
+		return -1
+	}
+
+	// #nosec G404 -- Pick an index with probability proportional to its weight
+	target := rng.Float64() * total
+	cumulative := 0.0
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+
+		cumulative += w
+		last = i
+		if cumulative > target {
+			return i
+		}
+	}
+
+	return last // rounding left the target past the last positive weight
+}
+
+// randomNonCandidate picks a uniformly random index in [0, n) that is not a candidate yet.
+func randomNonCandidate(rng *rand.Rand, n int, candidates []int) int {
+	taken := make(map[int]bool, len(candidates))
+	for _, c := range candidates {
+		taken[c] = true
+	}
+
+	// #nosec G404 -- Random permutation of indices
+	for _, i := range rng.Perm(n) {
+		if !taken[i] {
+			return i
+		}
+	}
+
+	return rng.Intn(n)
+}
+
+// firstNotChosen returns the lowest index not marked as chosen.
+func firstNotChosen(chosen []bool) int {
+	for i, c := range chosen {
+		if !c {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// sum adds up all values.
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+
+	return total
+}
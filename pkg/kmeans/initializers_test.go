@@ -1,6 +1,7 @@
 package kmeans_test
 
 import (
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -112,6 +113,92 @@ func (s *InitializersSuite) TestSmartCentroids5D() {
 	}
 }
 
+type SeededInitializersSuite struct {
+	suite.Suite
+}
+
+func TestSeededInitializersSuite(t *testing.T) {
+	suite.Run(t, new(SeededInitializersSuite))
+}
+
+func (s *SeededInitializersSuite) TestSeededRandomCentroidsReproducible() {
+	points := line(50)
+	first := kmeans.SeededRandomCentroids(points, 5, kmeans.InitConfig{Rand: rand.New(rand.NewSource(7))})
+	second := kmeans.SeededRandomCentroids(points, 5, kmeans.InitConfig{Rand: rand.New(rand.NewSource(7))})
+
+	s.Equal(first, second)
+	for _, c := range first {
+		s.True(pointInSlice(c, points), "centroid %+v not in dataset", c)
+	}
+}
+
+func (s *SeededInitializersSuite) TestSeededSmartCentroidsReproducible() {
+	points := line(50)
+	first := kmeans.SeededSmartCentroids(points, 5, kmeans.InitConfig{Rand: rand.New(rand.NewSource(7))})
+	second := kmeans.SeededSmartCentroids(points, 5, kmeans.InitConfig{Rand: rand.New(rand.NewSource(7))})
+
+	s.Equal(first, second)
+	for _, c := range first {
+		s.True(pointInSlice(c, points), "centroid %+v not in dataset", c)
+	}
+}
+
+func (s *SeededInitializersSuite) TestSeededInitializersWithoutRand() {
+	points := line(50)
+
+	s.Len(kmeans.SeededRandomCentroids(points, 5, kmeans.InitConfig{}), 5)
+	s.Len(kmeans.SeededSmartCentroids(points, 5, kmeans.InitConfig{}), 5)
+}
+
+func (s *SeededInitializersSuite) TestParallelPlusPlusCentroids() {
+	points := randomPoints(1000, 3, 17)
+	centroids := kmeans.ParallelPlusPlusCentroids(points, 10)
+	s.Len(centroids, 10)
+
+	for i, c := range centroids {
+		s.True(pointInSlice(c, points), "centroid %+v not in dataset", c)
+		s.False(pointInSlice(c, centroids[:i]), "centroid %+v picked twice", c)
+	}
+}
+
+func (s *SeededInitializersSuite) TestSeededParallelPlusPlusCentroidsReproducible() {
+	points := randomPoints(1000, 3, 17)
+	first := kmeans.SeededParallelPlusPlusCentroids(points, 10, kmeans.InitConfig{Rand: rand.New(rand.NewSource(3))})
+	second := kmeans.SeededParallelPlusPlusCentroids(points, 10,
+		kmeans.InitConfig{Rand: rand.New(rand.NewSource(3)), Workers: 4})
+
+	s.Equal(first, second)
+}
+
+func (s *SeededInitializersSuite) TestParallelPlusPlusCentroidsOnePerBlob() {
+	centers := []kmeans.Point{{0, 0}, {100, 0}, {0, 100}, {100, 100}, {50, 50}}
+	points := gaussianBlobs(centers, 200, 1, 9)
+
+	centroids := kmeans.SeededParallelPlusPlusCentroids(points, 5, kmeans.InitConfig{Rand: rand.New(rand.NewSource(1))})
+	for _, center := range centers {
+		found := false
+		for _, c := range centroids {
+			found = found || kmeans.Euclidean.Distance(c, center) < 10
+		}
+		s.True(found, "no centroid near %+v", center)
+	}
+}
+
+func (s *SeededInitializersSuite) TestParallelPlusPlusCentroidsWithDuplicates() {
+	points := []kmeans.Point{{1, 1}, {1, 1}, {1, 1}, {1, 1}, {2, 2}}
+	centroids := kmeans.ParallelPlusPlusCentroids(points, 3)
+
+	s.Len(centroids, 3)
+	s.True(pointInSlice(kmeans.Point{2, 2}, centroids))
+}
+
+func (s *SeededInitializersSuite) TestParallelPlusPlusCentroidsInKMeans() {
+	centroids, assignments := kmeans.KMeans(twoBlobs(), 2, 10, kmeans.ParallelPlusPlusCentroids)
+
+	s.Len(centroids, 2)
+	s.NotEqual(assignments[0], assignments[3])
+}
+
 func pointInSlice(p kmeans.Point, list []kmeans.Point) bool {
 	for _, el := range list {
 		if reflect.DeepEqual(p, el) {
@@ -1,15 +1,40 @@
 package kmeans
 
 import (
+	"context"
+	"fmt"
 	"math"
+	"slices"
 )
 
+// contextCheckInterval is the number of points assigned between two context checks.
+const contextCheckInterval = 4096
+
 // Point represents a point in n-dimensional space.
 // Example: [2.5, 3.1, 0.8] is a 3-dimensional point.
 type Point []float64
 type InitializeCentroidsFunction func(points []Point, k int) []Point
 
+// Result describes the outcome of a clustering run.
+type Result struct {
+	Centroids    []Point    // final positions of the cluster centroids
+	Assignments  []int      // index of the centroid assigned to each point
+	SSE          float64    // within-cluster sum of squared errors of the final partition
+	Iterations   int        // number of assignment/update passes actually run
+	ClusterSizes []int      // number of points assigned to each cluster
+	Converged    bool       // true if the run stopped on a convergence criterion before the iteration cap
+	StopReason   StopReason // criterion that ended the run
+	RestartSSE   []float64  // SSE of every completed restart in order, see WithRestarts
+	// EmptyClusters counts how many times a cluster was left without points by an assignment pass.
+	EmptyClusters int
+}
+
 // KMeans performs k-means clustering on the given dataset.
+// It is a thin wrapper around Fit kept for backwards compatibility:
+// invalid input (see ValidatePoints) yields nil centroids and assignments
+// instead of an error. As before, iterations <= 0 returns the initial centroids
+// with every point assigned to cluster 0, where Fit returns ErrInvalidNumberOfIterations.
+// Use Fit to get the error and the full Result, or to weight the points with WithWeights.
 //
 // Parameters:
 // - points: a slice of n-dimensional data points to cluster.
@@ -21,36 +46,285 @@ type InitializeCentroidsFunction func(points []Point, k int) []Point
 // - centroids: the final positions of the cluster centroids.
 // - assignments: a slice mapping each point to its assigned cluster index.
 func KMeans(points []Point, k, iterations int, initializeCentroids InitializeCentroidsFunction) ([]Point, []int) {
-	centroids := initializeCentroids(points, k)
+	if iterations <= 0 {
+		if ValidatePoints(points, k) != nil {
+			return nil, nil
+		}
+
+		return clonePoints(initializeCentroids(points, k)), make([]int, len(points))
+	}
+
+	result, err := Fit(points, WithK(k), WithMaxIterations(iterations), WithInitializer(initializeCentroids))
+	if err != nil {
+		return nil, nil
+	}
+
+	return result.Centroids, result.Assignments
+}
+
+// Fit performs k-means clustering on the given dataset using Lloyd's algorithm,
+// or the algorithm chosen with WithAlgorithm.
+// It is equivalent to FitContext with a background context.
+// The metric set with WithMetric must have the mean as its optimal centroid,
+// otherwise ErrMetricNotMeanCompatible is returned.
+//
+// Parameters:
+// - points: a slice of n-dimensional data points to cluster.
+// - opts: run configuration; WithK is required, everything else has a default.
+//
+// Returns:
+// - result: centroids, assignments and statistics of the run.
+// - err: one of the Err* validation errors if the input or options are invalid.
+func Fit(points []Point, opts ...Option) (*Result, error) {
+	return FitContext(context.Background(), points, opts...)
+}
+
+// FitContext performs k-means clustering like Fit, but stops as soon as ctx is done.
+// The context is checked between iterations and periodically inside the assignment step.
+//
+// When the context is cancelled or its deadline passes, FitContext returns ctx.Err()
+// together with a partial Result: the centroids of the last completed iteration
+// (the initial centroids if none completed) and StopReason set to StopCancelled.
+// Assignments, ClusterSizes and SSE are only filled in if at least one assignment pass finished.
+//
+// Parameters:
+// - ctx: controls cancellation and deadline of the run.
+// - points: a slice of n-dimensional data points to cluster.
+// - opts: run configuration; WithK is required, everything else has a default.
+//
+// Returns:
+// - result: centroids, assignments and statistics of the run; nil only for invalid input.
+// - err: one of the Err* validation errors, or ctx.Err() if the run was interrupted.
+func FitContext(ctx context.Context, points []Point, opts ...Option) (*Result, error) {
+	cfg := newConfig(opts)
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if !cfg.metric.MeanCentroid() {
+		return nil, fmt.Errorf("%w: %s", ErrMetricNotMeanCompatible, metricName(cfg.metric))
+	}
+
+	return fit(ctx, points, &cfg)
+}
+
+// fit performs the validated clustering run, or the best of cfg.restarts runs.
+func fit(ctx context.Context, points []Point, cfg *config) (*Result, error) {
+	if cfg.restarts > 1 {
+		return restart(ctx, points, cfg)
+	}
+
+	result, err := run(ctx, points, cfg)
+	if err == nil {
+		result.RestartSSE = []float64{result.SSE}
+	}
+
+	return result, err
+}
+
+// run performs a single clustering run with the configured algorithm.
+func run(ctx context.Context, points []Point, cfg *config) (*Result, error) {
+	if cfg.algorithm == MiniBatch {
+		return miniBatch(ctx, points, cfg)
+	}
+
+	return lloyd(ctx, points, cfg)
+}
+
+// assigner performs the assignment step of the Lloyd loop.
+// Accelerated variants keep distance bounds between iterations, but must produce exactly
+// the assignments of the brute-force nearest-centroid search. The size-constrained step
+// instead gives the cheapest assignment that respects the cluster size bounds.
+type assigner interface {
+	// assign moves every point to its nearest centroid and reports whether any assignment changed.
+	assign(ctx context.Context, centroids []Point) (bool, error)
+	// moved is called after each update step with the centroids before and after it.
+	moved(previous, centroids []Point)
+	// reassigned is called when point i was moved to another cluster outside the assignment step.
+	reassigned(i int)
+}
+
+// bruteForce is the plain assignment step comparing every point with every centroid.
+type bruteForce struct {
+	points      []Point
+	assignments []int
+	cfg         *config
+}
+
+func (b *bruteForce) assign(ctx context.Context, centroids []Point) (bool, error) {
+	return assignPoints(ctx, b.points, centroids, b.assignments, b.cfg)
+}
+
+func (b *bruteForce) moved(_, _ []Point) {}
+
+func (b *bruteForce) reassigned(int) {}
+
+// newAssigner returns the assignment step of the configured algorithm.
+func newAssigner(points []Point, assignments []int, cfg *config) assigner {
+	switch {
+	case cfg.constrained:
+		return &constrained{points: points, assignments: assignments, cfg: cfg}
+	case cfg.algorithm == Elkan:
+		return newElkan(points, assignments, cfg)
+	case cfg.algorithm == Hamerly:
+		return newHamerly(points, assignments, cfg)
+	default:
+		return &bruteForce{points: points, assignments: assignments, cfg: cfg}
+	}
+}
+
+// lloyd runs the classic assignment/update iterations until one of the stop criteria is met
+// or the context is done. The assignment step is brute force or one of the accelerated variants.
+func lloyd(ctx context.Context, points []Point, cfg *config) (*Result, error) {
+	centroids := clonePoints(cfg.initializer(points, cfg.k, cfg.initConfig()))
 	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = -1 // no point is assigned before the first pass
+	}
+	step := newAssigner(points, assignments, cfg)
+
+	result := &Result{Centroids: centroids}
+	check := newConvergenceCheck(cfg)
+	for result.Iterations < cfg.maxIterations {
+		if err := ctx.Err(); err != nil {
+			return interrupted(result, points, assignments, cfg), err
+		}
+
+		changed, err := step.assign(ctx, centroids)
+		if err != nil {
+			return interrupted(result, points, assignments, cfg), err
+		}
+
+		// Reseeding and the update step replace moved centroids instead of modifying them,
+		// so a shallow copy keeps the previous positions.
+		previous := slices.Clone(centroids)
+
+		empty, reseeded, err := handleEmptyClusters(points, centroids, assignments, cfg)
+		result.EmptyClusters += empty
+		if err != nil {
+			return failed(result, points, assignments, cfg), err
+		}
+		for _, i := range reseeded {
+			step.reassigned(i)
+			changed = true
+		}
+
+		shift := update(points, centroids, assignments, cfg)
+		if len(reseeded) > 0 {
+			shift = slices.Max(centroidShifts(previous, centroids)) // include the jump of reseeded centroids
+		}
+		step.moved(previous, centroids)
+		result.Iterations++
+
+		reason, stop := check.stop(changed, shift, func() float64 {
+			return weightedSSE(points, centroids, assignments, cfg.weights, cfg.metric)
+		})
+		if stop {
+			result.StopReason = reason
+			result.Converged = true
+			break
+		}
+	}
+
+	finalize(result, points, assignments, cfg)
+
+	return result, nil
+}
+
+// interrupted marks the result of a cancelled run and fills in whatever is usable.
+// Assignments are only reported once a full pass has assigned every point.
+func interrupted(result *Result, points []Point, assignments []int, cfg *config) *Result {
+	result.StopReason = StopCancelled
+	if !slices.Contains(assignments, -1) {
+		finalize(result, points, assignments, cfg)
+	}
 
-	for iter := 0; iter < iterations; iter++ {
-		// Create empty clusters
-		clusters := make([][]Point, k)
+	return result
+}
+
+// failed fills in the result of a run stopped by an error other than cancellation.
+// The assignments of the last pass are complete, so all statistics are reported.
+func failed(result *Result, points []Point, assignments []int, cfg *config) *Result {
+	finalize(result, points, assignments, cfg)
+	return result
+}
 
-		// Assign each point to the nearest centroid
-		for i, point := range points {
-			minDist := math.MaxFloat64
-			closestIndex := -1
+// finalize stores the assignments in the result and computes the derived statistics.
+func finalize(result *Result, points []Point, assignments []int, cfg *config) {
+	result.Assignments = assignments
+	result.SSE = weightedSSE(points, result.Centroids, assignments, cfg.weights, cfg.metric)
+	result.ClusterSizes = clusterSizes(assignments, cfg.k)
+}
+
+// nearestCentroid returns the index of the centroid closest to the point under the metric.
+// Ties go to the centroid with the lowest index.
+func nearestCentroid(point Point, centroids []Point, metric Metric) int {
+	minDist := metric.Distance(point, centroids[0])
+	closestIndex := 0
 
-			for index, centroid := range centroids {
-				distance := distance(point, centroid)
-				if distance < minDist {
-					minDist = distance
-					closestIndex = index
-				}
-			}
-			clusters[closestIndex] = append(clusters[closestIndex], point)
-			assignments[i] = closestIndex
+	for index, centroid := range centroids[1:] {
+		distance := metric.Distance(point, centroid)
+		if distance < minDist {
+			minDist = distance
+			closestIndex = index + 1
 		}
+	}
+
+	return closestIndex
+}
+
+// update performs the update step of the run: the mean, the coordinate-wise median for KMedians,
+// or the mean projected onto the unit sphere for SphericalKMeans.
+func update(points, centroids []Point, assignments []int, cfg *config) float64 {
+	switch {
+	case cfg.medians:
+		return updateMedians(points, centroids, assignments, cfg.workers)
+	case cfg.spherical:
+		return updateSphericalCentroids(points, centroids, assignments, cfg.weights, cfg.workers)
+	}
+
+	return updateCentroids(points, centroids, assignments, cfg.weights, cfg.workers)
+}
 
-		// Update centroids by calculating the mean of assigned points
-		for j := range centroids {
-			if len(clusters[j]) > 0 {
-				centroids[j] = mean(clusters[j])
-			}
+// updateCentroids moves every centroid to the (weighted) mean of its assigned points.
+// A centroid without any points, or whose points all have zero weight, keeps its previous position.
+// It returns the largest distance any centroid moved.
+func updateCentroids(points, centroids []Point, assignments []int, weights []float64, workers int) float64 {
+	sums, totals := clusterSums(points, assignments, weights, len(centroids), workers)
+
+	maxShift := 0.0
+	for j, sum := range sums {
+		if totals[j] == 0 {
+			continue
+		}
+
+		// Divide by the number of points (total weight) to get the average
+		for d := range sum {
+			sum[d] /= totals[j]
 		}
+		maxShift = math.Max(maxShift, distance(centroids[j], sum))
+		centroids[j] = sum
+	}
+
+	return maxShift
+}
+
+// clusterSizes counts how many points are assigned to each of the k clusters.
+func clusterSizes(assignments []int, k int) []int {
+	sizes := make([]int, k)
+	for _, a := range assignments {
+		sizes[a]++
+	}
+
+	return sizes
+}
+
+// clonePoints returns a deep copy of the points so results never alias the input dataset.
+func clonePoints(points []Point) []Point {
+	cloned := make([]Point, len(points))
+	for i, p := range points {
+		cloned[i] = append(Point(nil), p...)
 	}
 
-	return centroids, assignments
+	return cloned
 }
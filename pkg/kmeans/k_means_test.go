@@ -0,0 +1,193 @@
+package kmeans_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type FitSuite struct {
+	suite.Suite
+}
+
+func TestFitSuite(t *testing.T) {
+	suite.Run(t, new(FitSuite))
+}
+
+// twoBlobs returns two well separated 2D groups of three points each.
+func twoBlobs() []kmeans.Point {
+	return []kmeans.Point{
+		{1.0, 1.0}, {1.5, 2.0}, {1.0, 1.5},
+		{8.0, 8.0}, {8.5, 9.0}, {9.0, 8.0},
+	}
+}
+
+// firstPoints is a deterministic initializer returning the first k points of the dataset.
+func firstPoints(points []kmeans.Point, k int) []kmeans.Point {
+	return points[:k]
+}
+
+func (s *FitSuite) TestFitTwoBlobs() {
+	points := twoBlobs()
+	result, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithInitializer(kmeans.RandomCentroids))
+	s.Require().NoError(err)
+
+	s.Len(result.Centroids, 2)
+	s.Len(result.Assignments, len(points))
+	s.Equal(result.Assignments[0], result.Assignments[1])
+	s.Equal(result.Assignments[0], result.Assignments[2])
+	s.Equal(result.Assignments[3], result.Assignments[4])
+	s.Equal(result.Assignments[3], result.Assignments[5])
+	s.NotEqual(result.Assignments[0], result.Assignments[3])
+	s.Equal([]int{3, 3}, result.ClusterSizes)
+	s.True(result.Converged)
+	s.InDelta(kmeans.CalculateSSE(points, result.Centroids, result.Assignments), result.SSE, 1e-9)
+}
+
+func (s *FitSuite) TestFitKnownCentroids() {
+	points := []kmeans.Point{{0, 0}, {10, 10}, {0, 2}, {10, 12}}
+	result, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithMaxIterations(5), kmeans.WithInitializer(firstPoints))
+	s.Require().NoError(err)
+
+	s.Equal([]kmeans.Point{{0, 1}, {10, 11}}, result.Centroids)
+	s.Equal([]int{0, 1, 0, 1}, result.Assignments)
+	s.InDelta(4.0, result.SSE, 1e-9)
+	s.Equal(2, result.Iterations)
+	s.True(result.Converged)
+}
+
+func (s *FitSuite) TestFitDoesNotModifyInput() {
+	points := []kmeans.Point{{0, 0}, {10, 10}, {0, 2}, {10, 12}}
+	_, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithInitializer(firstPoints))
+	s.Require().NoError(err)
+
+	s.Equal([]kmeans.Point{{0, 0}, {10, 10}, {0, 2}, {10, 12}}, points)
+}
+
+func (s *FitSuite) TestFitSingleIterationNotConverged() {
+	points := []kmeans.Point{{0, 0}, {10, 10}, {0, 2}, {10, 12}}
+	result, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithMaxIterations(1), kmeans.WithInitializer(firstPoints))
+	s.Require().NoError(err)
+
+	s.Equal(1, result.Iterations)
+	s.False(result.Converged)
+	s.Equal(kmeans.StopMaxIterations, result.StopReason)
+}
+
+func (s *FitSuite) TestFitInvalidInputReturnsError() {
+	_, err := kmeans.Fit([]kmeans.Point{}, kmeans.WithK(2))
+	s.ErrorIs(err, kmeans.ErrNoPoints)
+
+	_, err = kmeans.Fit(twoBlobs())
+	s.ErrorIs(err, kmeans.ErrNegativeNumberOfClusters)
+
+	_, err = kmeans.Fit(twoBlobs(), kmeans.WithK(10))
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+
+	_, err = kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithMaxIterations(0))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfIterations)
+}
+
+func (s *FitSuite) TestFitIgnoresOptionsOfOtherModes() {
+	result, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithSignificance(0), kmeans.WithBatchSize(0),
+		kmeans.WithGapReferences(0), kmeans.WithFuzzifier(1), kmeans.WithSamples(0))
+	s.Require().NoError(err)
+	s.Len(result.Centroids, 2)
+}
+
+func (s *FitSuite) TestKMeansWrapper() {
+	points := []kmeans.Point{{0, 0}, {10, 10}, {0, 2}, {10, 12}}
+	centroids, assignments := kmeans.KMeans(points, 2, 10, firstPoints)
+
+	s.Equal([]kmeans.Point{{0, 1}, {10, 11}}, centroids)
+	s.Equal([]int{0, 1, 0, 1}, assignments)
+}
+
+func (s *FitSuite) TestKMeansWrapperWithoutIterations() {
+	points := []kmeans.Point{{0, 0}, {10, 10}, {0, 2}, {10, 12}}
+	centroids, assignments := kmeans.KMeans(points, 2, 0, firstPoints)
+
+	s.Equal([]kmeans.Point{{0, 0}, {10, 10}}, centroids)
+	s.Equal([]int{0, 0, 0, 0}, assignments)
+}
+
+func (s *FitSuite) TestKMeansWrapperInvalidInput() {
+	centroids, assignments := kmeans.KMeans([]kmeans.Point{{1, 2}}, 2, 10, kmeans.RandomCentroids)
+
+	s.Nil(centroids)
+	s.Nil(assignments)
+}
+
+func (s *FitSuite) TestFitWithSeedIsReproducible() {
+	points := line(200)
+	for _, initializer := range []kmeans.SeededInitializer{kmeans.SeededRandomCentroids, kmeans.SeededSmartCentroids} {
+		opts := []kmeans.Option{kmeans.WithK(7), kmeans.WithSeed(42), kmeans.WithSeededInitializer(initializer)}
+
+		first, err := kmeans.Fit(points, opts...)
+		s.Require().NoError(err)
+		second, err := kmeans.Fit(points, opts...)
+		s.Require().NoError(err)
+
+		s.Equal(first.Centroids, second.Centroids)
+		s.Equal(first.Assignments, second.Assignments)
+		s.Equal(first.Iterations, second.Iterations)
+	}
+}
+
+// expiringContext reports cancellation after Err has been called a given number of times.
+type expiringContext struct {
+	context.Context
+	remaining int
+}
+
+func (c *expiringContext) Err() error {
+	if c.remaining <= 0 {
+		return context.Canceled
+	}
+	c.remaining--
+	return nil
+}
+
+func (s *FitSuite) TestFitContextAlreadyCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := kmeans.FitContext(ctx, twoBlobs(), kmeans.WithK(2), kmeans.WithInitializer(firstPoints))
+	s.ErrorIs(err, context.Canceled)
+	s.Require().NotNil(result)
+
+	s.Equal(kmeans.StopCancelled, result.StopReason)
+	s.False(result.Converged)
+	s.Equal(0, result.Iterations)
+	s.Equal([]kmeans.Point{{1.0, 1.0}, {1.5, 2.0}}, result.Centroids)
+	s.Nil(result.Assignments)
+}
+
+func (s *FitSuite) TestFitContextReturnsPartialResult() {
+	ctx := &expiringContext{Context: context.Background(), remaining: 1}
+
+	result, err := kmeans.FitContext(ctx, line(100), kmeans.WithK(2), kmeans.WithInitializer(firstPoints))
+	s.ErrorIs(err, context.Canceled)
+	s.Require().NotNil(result)
+
+	s.Equal(kmeans.StopCancelled, result.StopReason)
+	s.Equal(1, result.Iterations)
+	s.Len(result.Centroids, 2)
+	s.Len(result.Assignments, 100)
+	s.Equal(100, result.ClusterSizes[0]+result.ClusterSizes[1])
+}
+
+func (s *FitSuite) TestFitContextInterruptsAssignmentOfLargeInput() {
+	// The first check happens before the first pass, the second one inside the assignment loop.
+	ctx := &expiringContext{Context: context.Background(), remaining: 1}
+
+	result, err := kmeans.FitContext(ctx, line(10000),
+		kmeans.WithK(2), kmeans.WithInitializer(firstPoints), kmeans.WithWorkers(1))
+	s.ErrorIs(err, context.Canceled)
+	s.Require().NotNil(result)
+
+	s.Equal(0, result.Iterations)
+	s.Nil(result.Assignments)
+}
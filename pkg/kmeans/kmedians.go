@@ -0,0 +1,118 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// KMedians performs k-medians clustering: points are assigned by Manhattan distance and every
+// centroid moves to the coordinate-wise median of its points, which minimises the total
+// Manhattan distance of the cluster. Medians are not dragged by outliers like means are.
+// It is equivalent to KMediansContext with a background context.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - opts: run configuration as for Fit; WithK is required, WithMetric is ignored
+//
+// Returns:
+//   - result: centroids, assignments and statistics of the run, as reported by Fit
+//   - err: one of the Err* validation errors if the input or options are invalid
+func KMedians(points []Point, opts ...Option) (*Result, error) {
+	return KMediansContext(context.Background(), points, opts...)
+}
+
+// KMediansContext performs k-medians clustering like KMedians, but stops as soon as ctx is done,
+// returning a partial Result as FitContext does.
+//
+// The stop criteria, initializers, restarts and empty cluster strategies are those of Fit.
+// Result.SSE and the SSE tolerance use squared Manhattan distances; the tolerance of
+// WithTolerance applies to the Euclidean movement of the centroids, as for k-means.
+// Only Lloyd iterations are supported: the accelerated algorithms rely on Euclidean
+// bounds and mini-batches on incremental means, so they return ErrMetricNotSupported.
+//
+// Arguments:
+//   - ctx: controls cancellation and deadline of the run
+//   - points: dataset of n-dimensional points
+//   - opts: run configuration as for Fit; WithK is required, WithMetric is ignored
+//
+// Returns:
+//   - result: centroids, assignments and statistics of the run; nil only for invalid input
+//   - err: one of the Err* validation errors, or ctx.Err() if the run was interrupted
+func KMediansContext(ctx context.Context, points []Point, opts ...Option) (*Result, error) {
+	cfg := newConfig(append(slices.Clone(opts), WithMetric(Manhattan)))
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.rejectWeights("k-medians"); err != nil {
+		return nil, err
+	}
+
+	if cfg.algorithm != Lloyd {
+		return nil, fmt.Errorf("%w: k-medians requires %s, got %s", ErrMetricNotSupported, Lloyd, cfg.algorithm)
+	}
+
+	cfg.medians = true
+
+	return fit(ctx, points, &cfg)
+}
+
+// updateMedians moves every centroid to the coordinate-wise median of its assigned points.
+// A centroid without any points keeps its previous position.
+// It returns the largest distance any centroid moved.
+//
+// The work is split by dimension, so the result does not depend on the number of workers.
+func updateMedians(points, centroids []Point, assignments []int, workers int) float64 {
+	members := make([][]int, len(centroids))
+	for i, a := range assignments {
+		members[a] = append(members[a], i)
+	}
+
+	dim := len(points[0])
+	medians := make([]Point, len(centroids))
+	for j, indices := range members {
+		if len(indices) > 0 {
+			medians[j] = make(Point, dim)
+		}
+	}
+
+	spans := splitRange(dim, effectiveWorkers(len(points), workers))
+	runSpans(spans, func(_ int, s span) {
+		var values []float64
+		for j, indices := range members {
+			for d := s.lo; d < s.hi && len(indices) > 0; d++ {
+				values = values[:0]
+				for _, i := range indices {
+					values = append(values, points[i][d])
+				}
+				medians[j][d] = median(values)
+			}
+		}
+	})
+
+	maxShift := 0.0
+	for j, m := range medians {
+		if m == nil {
+			continue
+		}
+
+		maxShift = math.Max(maxShift, distance(centroids[j], m))
+		centroids[j] = m
+	}
+
+	return maxShift
+}
+
+// median returns the median of the values, the mean of the two middle ones for an even count.
+// It sorts the values in place.
+func median(values []float64) float64 {
+	slices.Sort(values)
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[middle-1] + values[middle]) / 2
+	}
+
+	return values[middle]
+}
@@ -0,0 +1,90 @@
+package kmeans_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type KMediansSuite struct {
+	suite.Suite
+}
+
+func TestKMediansSuite(t *testing.T) {
+	suite.Run(t, new(KMediansSuite))
+}
+
+// heavyTailed returns two groups of five points, the second with an extreme outlier.
+func heavyTailed() []kmeans.Point {
+	return []kmeans.Point{
+		{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 2},
+		{10, 10}, {11, 10}, {10, 11}, {11, 12}, {1000, 10},
+	}
+}
+
+func (s *KMediansSuite) TestCentroidsAreMedians() {
+	start := func(_ []kmeans.Point, _ int) []kmeans.Point { return []kmeans.Point{{0, 0}, {10, 10}} }
+	result, err := kmeans.KMedians(heavyTailed(), kmeans.WithK(2), kmeans.WithInitializer(start))
+	s.Require().NoError(err)
+
+	s.True(result.Converged)
+	s.Equal([]int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1}, result.Assignments)
+	s.Equal(kmeans.Point{1, 1}, result.Centroids[0])
+	s.Equal(kmeans.Point{11, 10}, result.Centroids[1]) // the mean would be {208.4, 10.6}
+	s.Equal([]int{5, 5}, result.ClusterSizes)
+}
+
+func (s *KMediansSuite) TestEvenClusterAveragesMiddleValues() {
+	points := []kmeans.Point{{0}, {1}, {3}, {10}, {100}, {101}, {103}, {110}}
+	result, err := kmeans.KMedians(points, kmeans.WithK(2), kmeans.WithSeed(2))
+	s.Require().NoError(err)
+
+	centroids := slices.Clone(result.Centroids)
+	slices.SortFunc(centroids, func(a, b kmeans.Point) int { return int(a[0] - b[0]) })
+	s.Equal([]kmeans.Point{{2}, {102}}, centroids)
+}
+
+func (s *KMediansSuite) TestSSEUsesManhattanDistance() {
+	points := heavyTailed()
+	result, err := kmeans.KMedians(points, kmeans.WithK(2), kmeans.WithSeed(3), kmeans.WithMetric(kmeans.Euclidean))
+	s.Require().NoError(err)
+
+	expected := kmeans.CalculateSSEWithMetric(points, result.Centroids, result.Assignments, kmeans.Manhattan)
+	s.InDelta(expected, result.SSE, 1e-9)
+}
+
+func (s *KMediansSuite) TestIndependentOfWorkers() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0, 0}, {8, 8, 0}, {0, 8, 8}}, 2000, 1, 5)
+
+	serial, err := kmeans.KMedians(points, kmeans.WithK(3), kmeans.WithSeed(4), kmeans.WithWorkers(1))
+	s.Require().NoError(err)
+	parallel, err := kmeans.KMedians(points, kmeans.WithK(3), kmeans.WithSeed(4), kmeans.WithWorkers(3))
+	s.Require().NoError(err)
+
+	s.Equal(serial.Centroids, parallel.Centroids)
+	s.Equal(serial.Assignments, parallel.Assignments)
+	s.Equal([]int{2000, 2000, 2000}, sortedSizes(serial.ClusterSizes))
+}
+
+func (s *KMediansSuite) TestRestartsKeepTheBestRun() {
+	points := fourBlobs()
+
+	result, err := kmeans.KMedians(points, kmeans.WithK(4), kmeans.WithSeed(5), kmeans.WithRestarts(4))
+	s.Require().NoError(err)
+
+	s.Len(result.RestartSSE, 4)
+	s.Equal(slices.Min(result.RestartSSE), result.SSE)
+}
+
+func (s *KMediansSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.KMedians(twoBlobs())
+	s.ErrorIs(err, kmeans.ErrNegativeNumberOfClusters)
+
+	_, err = kmeans.KMedians(twoBlobs(), kmeans.WithK(2), kmeans.WithAlgorithm(kmeans.Elkan))
+	s.ErrorIs(err, kmeans.ErrMetricNotSupported)
+
+	_, err = kmeans.KMedians(twoBlobs(), kmeans.WithK(2), kmeans.WithAlgorithm(kmeans.MiniBatch))
+	s.ErrorIs(err, kmeans.ErrMetricNotSupported)
+}
@@ -0,0 +1,428 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// MedoidsMethod selects the algorithm of KMedoids.
+type MedoidsMethod int
+
+const (
+	// PAM is Partitioning Around Medoids (Kaufman and Rousseeuw): the greedy BUILD initialization,
+	// then SWAP passes that perform the best medoid/non-medoid exchange until none improves.
+	// Every pass costs O(k·n²) dissimilarities.
+	PAM MedoidsMethod = iota
+	// FasterPAM (Schubert and Rousseeuw) starts from random medoids and performs every improving
+	// swap as soon as it is found, evaluating all k removals of a candidate at once in O(n).
+	// It usually reaches a solution as good as PAM's in a fraction of the time.
+	FasterPAM
+	// CLARA (Clustering LARge Applications) runs PAM on several random samples of the objects,
+	// each containing the best medoids found so far, and keeps the medoids with the lowest
+	// deviation over the whole dataset. It needs O(n·k) dissimilarities per sample, not O(n²).
+	CLARA
+	// CLARANS (Ng and Han) searches from random medoids, trying random medoid/non-medoid swaps and
+	// performing the first improving one, until a number of consecutive attempts fail. It repeats
+	// the search from several random starts and keeps the best local minimum.
+	CLARANS
+)
+
+// String returns a human-readable name of the method.
+func (m MedoidsMethod) String() string {
+	switch m {
+	case PAM:
+		return "pam"
+	case FasterPAM:
+		return "fasterpam"
+	case CLARA:
+		return "clara"
+	case CLARANS:
+		return "clarans"
+	default:
+		return fmt.Sprintf("MedoidsMethod(%d)", int(m))
+	}
+}
+
+// MedoidsResult describes the outcome of a k-medoids clustering.
+type MedoidsResult struct {
+	Medoids      []int   // dataset indices of the medoids, the representatives of the clusters
+	Assignments  []int   // index in Medoids of the medoid assigned to each point
+	Deviation    float64 // total deviation: sum of the dissimilarities of every point to its medoid
+	ClusterSizes []int   // number of points assigned to each medoid
+	Swaps        int     // number of medoid swaps performed, over all samples or searches for CLARA and CLARANS
+	Converged    bool    // true if the swaps stopped at a local minimum rather than at the iteration cap
+}
+
+// KMedoids clusters the points around k of the points themselves, minimizing the total
+// dissimilarity under the metric set with WithMetric (Euclidean by default). Unlike Fit it
+// accepts any metric, since no mean is ever computed. PAM and FasterPAM compute O(n²)
+// dissimilarities; for large datasets CLARA and CLARANS only need O(n·k) per sample or swap.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - k: the number of clusters to form
+//   - method: PAM, FasterPAM, CLARA or CLARANS
+//   - opts: WithMetric, WithMaxIterations (SWAP passes), WithSeed or WithRand (random initialization
+//     and sampling), WithWorkers, and the sampling options WithSamples, WithSampleSize (CLARA),
+//     WithLocalSearches and WithMaxNeighbors (CLARANS)
+//
+// Returns:
+//   - result: medoid indices, assignments and total deviation
+//   - err: one of the Err* validation errors
+func KMedoids(points []Point, k int, method MedoidsMethod, opts ...Option) (*MedoidsResult, error) {
+	cfg := newConfig(append(slices.Clone(opts), WithK(k)))
+	if err := cfg.validateBase(points); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateSampling(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.rejectWeights("k-medoids"); err != nil {
+		return nil, err
+	}
+
+	return kMedoids(pointDissimilarities{points: points, metric: cfg.metric}, method, &cfg)
+}
+
+// KMedoidsMatrix clusters n objects around k of them like KMedoids, given their precomputed
+// n×n dissimilarity matrix instead of points. The matrix does not need to be symmetric:
+// dissimilarities[i][j] is the cost of assigning object i to medoid j.
+//
+// Arguments:
+//   - dissimilarities: n×n matrix of non-negative, finite dissimilarities
+//   - k: the number of clusters to form
+//   - method: PAM, FasterPAM, CLARA or CLARANS
+//   - opts: WithMaxIterations (SWAP passes), WithSeed or WithRand (random initialization and
+//     sampling), WithWorkers, and the sampling options of KMedoids
+//
+// Returns:
+//   - result: medoid indices, assignments and total deviation
+//   - err: one of the Err* validation errors, or ErrInvalidDissimilarityMatrix
+func KMedoidsMatrix(dissimilarities [][]float64, k int, method MedoidsMethod, opts ...Option) (*MedoidsResult, error) {
+	cfg := newConfig(append(slices.Clone(opts), WithK(k)))
+	if err := validateDissimilarities(dissimilarities, &cfg); err != nil {
+		return nil, err
+	}
+
+	return kMedoids(matrixDissimilarities(dissimilarities), method, &cfg)
+}
+
+// validateDissimilarities checks the matrix and the options that apply to it.
+func validateDissimilarities(dissimilarities [][]float64, cfg *config) error {
+	n := len(dissimilarities)
+	if n == 0 {
+		return ErrNoPoints
+	}
+
+	if cfg.k <= 0 {
+		return ErrNegativeNumberOfClusters
+	}
+
+	if n < cfg.k {
+		return ErrNotEnoughPoints
+	}
+
+	if cfg.maxIterations <= 0 {
+		return ErrInvalidNumberOfIterations
+	}
+
+	if cfg.workers <= 0 {
+		return ErrInvalidNumberOfWorkers
+	}
+
+	if err := cfg.validateSampling(); err != nil {
+		return err
+	}
+
+	if err := cfg.rejectWeights("k-medoids"); err != nil {
+		return err
+	}
+
+	for i, row := range dissimilarities {
+		if len(row) != n {
+			return fmt.Errorf("%w: row %d has %d entries, want %d", ErrInvalidDissimilarityMatrix, i, len(row), n)
+		}
+		for j, d := range row {
+			if !(d >= 0) || math.IsInf(d, 1) {
+				return fmt.Errorf("%w: entry [%d][%d] is %g", ErrInvalidDissimilarityMatrix, i, j, d)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dissimilarities gives the cost of assigning object i to medoid j.
+type dissimilarities interface {
+	len() int
+	between(i, j int) float64
+}
+
+// pointDissimilarities computes the dissimilarities of points with a metric on demand.
+type pointDissimilarities struct {
+	points []Point
+	metric Metric
+}
+
+func (p pointDissimilarities) len() int { return len(p.points) }
+
+func (p pointDissimilarities) between(i, j int) float64 {
+	return p.metric.Distance(p.points[i], p.points[j])
+}
+
+// matrixDissimilarities looks the dissimilarities up in a precomputed matrix.
+type matrixDissimilarities [][]float64
+
+func (m matrixDissimilarities) len() int { return len(m) }
+
+func (m matrixDissimilarities) between(i, j int) float64 { return m[i][j] }
+
+// kMedoids runs the chosen method on the validated dissimilarities.
+func kMedoids(d dissimilarities, method MedoidsMethod, cfg *config) (*MedoidsResult, error) {
+	state := &medoidState{d: d, workers: cfg.workers}
+	switch method {
+	case PAM:
+		state.build(cfg.k)
+		state.pamSwap(cfg.maxIterations)
+	case FasterPAM:
+		state.random(cfg.k, cfg.rng)
+		state.fasterSwap(cfg.maxIterations)
+	case CLARA:
+		state.clara(cfg)
+	case CLARANS:
+		state.clarans(cfg)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMedoidsMethod, method)
+	}
+
+	return state.result(), nil
+}
+
+// medoidState holds the medoids and, for every object, its nearest and second nearest medoid.
+type medoidState struct {
+	d         dissimilarities
+	medoids   []int     // object indices of the medoids
+	nearest   []int     // index in medoids of the nearest medoid of every object
+	dNearest  []float64 // dissimilarity to the nearest medoid
+	dSecond   []float64 // dissimilarity to the second nearest medoid; +Inf for k = 1
+	swaps     int
+	converged bool
+	workers   int // goroutines sharing the assignment of the objects
+}
+
+// build selects the initial medoids greedily: first the object with the smallest total
+// dissimilarity, then every time the object that reduces the total deviation the most.
+func (s *medoidState) build(k int) {
+	n := s.d.len()
+	current := make([]float64, n)
+	for i := range current {
+		current[i] = math.Inf(1)
+	}
+
+	chosen := make([]bool, n)
+	for range k {
+		best, bestTotal := -1, math.Inf(1)
+		for c := range n {
+			if chosen[c] {
+				continue
+			}
+
+			// Total deviation if c were added to the medoids chosen so far
+			total := 0.0
+			for j := range n {
+				total += min(current[j], s.d.between(j, c))
+			}
+			if total < bestTotal {
+				best, bestTotal = c, total
+			}
+		}
+
+		chosen[best] = true
+		s.medoids = append(s.medoids, best)
+		for j := range n {
+			current[j] = min(current[j], s.d.between(j, best))
+		}
+	}
+
+	s.assign()
+}
+
+// random selects k distinct objects uniformly as the initial medoids.
+func (s *medoidState) random(k int, rng *rand.Rand) {
+	s.medoids = rng.Perm(s.d.len())[:k]
+	s.assign()
+}
+
+// assign finds the nearest and second nearest medoid of every object. Ties go to the lowest index.
+func (s *medoidState) assign() {
+	n := s.d.len()
+	if len(s.nearest) != n {
+		s.nearest = make([]int, n)
+		s.dNearest = make([]float64, n)
+		s.dSecond = make([]float64, n)
+	}
+
+	_, _ = parallelPass(context.Background(), n, s.workers, func(j int) bool {
+		s.nearest[j], s.dNearest[j], s.dSecond[j] = -1, math.Inf(1), math.Inf(1)
+		for m, medoid := range s.medoids {
+			d := s.d.between(j, medoid)
+			if d < s.dNearest[j] || s.nearest[j] < 0 {
+				s.nearest[j], s.dNearest[j], s.dSecond[j] = m, d, s.dNearest[j]
+			} else if d < s.dSecond[j] {
+				s.dSecond[j] = d
+			}
+		}
+		return false
+	})
+}
+
+// deviation returns the sum of the dissimilarities of all objects to their nearest medoid.
+func (s *medoidState) deviation() float64 {
+	total := 0.0
+	for _, d := range s.dNearest {
+		total += d
+	}
+
+	return total
+}
+
+// isMedoid reports whether the object is one of the medoids.
+func (s *medoidState) isMedoid(object int) bool {
+	return slices.Contains(s.medoids, object)
+}
+
+// pamSwap performs, pass after pass, the single swap that reduces the deviation the most.
+func (s *medoidState) pamSwap(maxIterations int) {
+	for range maxIterations {
+		bestDelta, bestMedoid, bestObject := 0.0, -1, -1
+		for h := range s.d.len() {
+			if s.isMedoid(h) {
+				continue
+			}
+
+			for m := range s.medoids {
+				if delta := s.swapDelta(m, h); delta < bestDelta {
+					bestDelta, bestMedoid, bestObject = delta, m, h
+				}
+			}
+		}
+
+		if bestMedoid < 0 {
+			s.converged = true
+			return
+		}
+
+		s.swap(bestMedoid, bestObject)
+	}
+}
+
+// swapDelta returns the change of the total deviation if medoid m were replaced by object h.
+func (s *medoidState) swapDelta(m, h int) float64 {
+	delta := 0.0
+	for j := range s.d.len() {
+		d := s.d.between(j, h)
+		if s.nearest[j] == m {
+			delta += min(d, s.dSecond[j]) - s.dNearest[j] // j loses its medoid
+		} else if d < s.dNearest[j] {
+			delta += d - s.dNearest[j] // j moves to h
+		}
+	}
+
+	return delta
+}
+
+// fasterSwap loops over the non-medoids and performs every improving swap immediately,
+// until a full pass over all objects finds none or maxIterations passes have run.
+func (s *medoidState) fasterSwap(maxIterations int) {
+	if len(s.medoids) == 1 {
+		s.pamSwap(maxIterations) // the removal loss of a single medoid is infinite
+		return
+	}
+
+	n := s.d.len()
+	removal := s.removalLoss()
+	lastSwap := -1
+	for pass := 0; pass < maxIterations; pass++ {
+		for h := range n {
+			if h == lastSwap {
+				s.converged = true // a full pass without any improving swap
+				return
+			}
+			if s.isMedoid(h) {
+				continue
+			}
+
+			m, delta := s.bestRemoval(h, removal)
+			if delta < 0 {
+				s.swap(m, h)
+				removal = s.removalLoss()
+				lastSwap = h
+			}
+		}
+
+		if lastSwap < 0 {
+			s.converged = true // the initial medoids cannot be improved
+			return
+		}
+	}
+}
+
+// removalLoss returns, for every medoid, how much the deviation grows if it is removed
+// and its objects move to their second nearest medoid.
+func (s *medoidState) removalLoss() []float64 {
+	loss := make([]float64, len(s.medoids))
+	for j, m := range s.nearest {
+		loss[m] += s.dSecond[j] - s.dNearest[j]
+	}
+
+	return loss
+}
+
+// bestRemoval evaluates adding object h for all k possible removals at once and returns
+// the medoid whose replacement by h changes the deviation the least (most negative).
+func (s *medoidState) bestRemoval(h int, removal []float64) (int, float64) {
+	delta := slices.Clone(removal)
+	shared := 0.0 // change common to all removals, from objects that move to h anyway
+	for j := range s.d.len() {
+		d := s.d.between(j, h)
+		if d < s.dNearest[j] {
+			shared += d - s.dNearest[j]
+			delta[s.nearest[j]] += s.dNearest[j] - s.dSecond[j]
+		} else if d < s.dSecond[j] {
+			delta[s.nearest[j]] += d - s.dSecond[j]
+		}
+	}
+
+	best := 0
+	for m := range delta {
+		if delta[m] < delta[best] {
+			best = m
+		}
+	}
+
+	return best, delta[best] + shared
+}
+
+// swap replaces medoid m by object h and reassigns all objects.
+func (s *medoidState) swap(m, h int) {
+	s.medoids[m] = h
+	s.swaps++
+	s.assign()
+}
+
+// result collects the final medoids, assignments and deviation.
+func (s *medoidState) result() *MedoidsResult {
+	return &MedoidsResult{
+		Medoids:      s.medoids,
+		Assignments:  s.nearest,
+		Swaps:        s.swaps,
+		Converged:    s.converged,
+		ClusterSizes: clusterSizes(s.nearest, len(s.medoids)),
+		Deviation:    s.deviation(),
+	}
+}
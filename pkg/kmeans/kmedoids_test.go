@@ -0,0 +1,164 @@
+package kmeans_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type KMedoidsSuite struct {
+	suite.Suite
+}
+
+func TestKMedoidsSuite(t *testing.T) {
+	suite.Run(t, new(KMedoidsSuite))
+}
+
+// threeGroups1D returns three groups of three points on a line; the middle point of each is its medoid.
+func threeGroups1D() []kmeans.Point {
+	return []kmeans.Point{{0}, {1}, {2}, {10}, {11}, {12}, {20}, {21}, {22}}
+}
+
+// dissimilarityMatrix returns the matrix of the metric distances between all points.
+func dissimilarityMatrix(points []kmeans.Point, metric kmeans.Metric) [][]float64 {
+	matrix := make([][]float64, len(points))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(points))
+		for j := range matrix[i] {
+			matrix[i][j] = metric.Distance(points[i], points[j])
+		}
+	}
+	return matrix
+}
+
+// optimalDeviation returns the smallest total deviation over all sets of k medoids.
+func optimalDeviation(matrix [][]float64, k int) float64 {
+	best := math.Inf(1)
+	var search func(start int, medoids []int)
+	search = func(start int, medoids []int) {
+		if len(medoids) == k {
+			total := 0.0
+			for j := range matrix {
+				nearest := math.Inf(1)
+				for _, m := range medoids {
+					nearest = min(nearest, matrix[j][m])
+				}
+				total += nearest
+			}
+			best = min(best, total)
+			return
+		}
+		for c := start; c < len(matrix); c++ {
+			search(c+1, append(medoids, c))
+		}
+	}
+	search(0, nil)
+	return best
+}
+
+func (s *KMedoidsSuite) TestFindsMiddlePoints() {
+	for _, method := range []kmeans.MedoidsMethod{kmeans.PAM, kmeans.FasterPAM} {
+		result, err := kmeans.KMedoids(threeGroups1D(), 3, method, kmeans.WithSeed(1))
+		s.Require().NoError(err, method.String())
+
+		s.ElementsMatch([]int{1, 4, 7}, result.Medoids, method.String())
+		s.InDelta(6.0, result.Deviation, 1e-12)
+		s.Equal([]int{3, 3, 3}, result.ClusterSizes)
+		s.True(result.Converged)
+		for i, a := range result.Assignments {
+			s.Equal(result.Medoids[a]/3, i/3) // every point is assigned to the medoid of its group
+		}
+	}
+}
+
+func (s *KMedoidsSuite) TestPAMBuildIsDeterministic() {
+	result, err := kmeans.KMedoids(threeGroups1D(), 3, kmeans.PAM)
+	s.Require().NoError(err)
+
+	// BUILD picks the overall medoid first, then the best additions; no swap is needed
+	s.Equal([]int{4, 1, 7}, result.Medoids)
+	s.Equal(0, result.Swaps)
+}
+
+func (s *KMedoidsSuite) TestReachesOptimumOnSmallData() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {6, 1}, {2, 7}}, 5, 1.5, 21)
+	matrix := dissimilarityMatrix(points, kmeans.Manhattan)
+	optimum := optimalDeviation(matrix, 3)
+
+	for _, method := range []kmeans.MedoidsMethod{kmeans.PAM, kmeans.FasterPAM} {
+		result, err := kmeans.KMedoids(points, 3, method, kmeans.WithMetric(kmeans.Manhattan), kmeans.WithSeed(2))
+		s.Require().NoError(err)
+		s.InDelta(optimum, result.Deviation, 1e-9, method.String())
+	}
+}
+
+func (s *KMedoidsSuite) TestMatrixMatchesPoints() {
+	points := fourBlobs()
+	matrix := dissimilarityMatrix(points, kmeans.Cosine)
+
+	for _, method := range []kmeans.MedoidsMethod{kmeans.PAM, kmeans.FasterPAM} {
+		fromPoints, err := kmeans.KMedoids(points, 4, method, kmeans.WithMetric(kmeans.Cosine), kmeans.WithSeed(3))
+		s.Require().NoError(err)
+		fromMatrix, err := kmeans.KMedoidsMatrix(matrix, 4, method, kmeans.WithSeed(3))
+		s.Require().NoError(err)
+
+		s.Equal(fromPoints.Medoids, fromMatrix.Medoids)
+		s.Equal(fromPoints.Assignments, fromMatrix.Assignments)
+		s.InDelta(fromPoints.Deviation, fromMatrix.Deviation, 1e-9)
+	}
+}
+
+func (s *KMedoidsSuite) TestFasterPAMMatchesPAMOnBlobs() {
+	points := fourBlobs()
+
+	pam, err := kmeans.KMedoids(points, 4, kmeans.PAM)
+	s.Require().NoError(err)
+	faster, err := kmeans.KMedoids(points, 4, kmeans.FasterPAM, kmeans.WithSeed(4))
+	s.Require().NoError(err)
+
+	s.ElementsMatch(pam.Medoids, faster.Medoids)
+	s.InDelta(pam.Deviation, faster.Deviation, 1e-9)
+}
+
+func (s *KMedoidsSuite) TestSingleMedoid() {
+	for _, method := range []kmeans.MedoidsMethod{kmeans.PAM, kmeans.FasterPAM} {
+		result, err := kmeans.KMedoids(threeGroups1D(), 1, method, kmeans.WithSeed(5))
+		s.Require().NoError(err)
+
+		s.Equal([]int{4}, result.Medoids)
+		s.InDelta(62.0, result.Deviation, 1e-12)
+	}
+}
+
+func (s *KMedoidsSuite) TestIterationCap() {
+	result, err := kmeans.KMedoids(fourBlobs(), 4, kmeans.FasterPAM, kmeans.WithSeed(6), kmeans.WithMaxIterations(1))
+	s.Require().NoError(err)
+
+	s.Len(result.Medoids, 4)
+	s.False(result.Converged)
+}
+
+func (s *KMedoidsSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.KMedoids(twoBlobs(), 7, kmeans.PAM)
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+
+	_, err = kmeans.KMedoids(twoBlobs(), 2, kmeans.MedoidsMethod(5))
+	s.ErrorIs(err, kmeans.ErrUnknownMedoidsMethod)
+
+	_, err = kmeans.KMedoidsMatrix(nil, 2, kmeans.PAM)
+	s.ErrorIs(err, kmeans.ErrNoPoints)
+
+	_, err = kmeans.KMedoidsMatrix([][]float64{{0, 1}, {1}}, 1, kmeans.PAM)
+	s.ErrorIs(err, kmeans.ErrInvalidDissimilarityMatrix)
+
+	_, err = kmeans.KMedoidsMatrix([][]float64{{0, -1}, {1, 0}}, 1, kmeans.PAM)
+	s.ErrorIs(err, kmeans.ErrInvalidDissimilarityMatrix)
+
+	_, err = kmeans.KMedoidsMatrix([][]float64{{0, math.NaN()}, {1, 0}}, 1, kmeans.PAM)
+	s.ErrorIs(err, kmeans.ErrInvalidDissimilarityMatrix)
+
+	_, err = kmeans.KMedoidsMatrix([][]float64{{0, 1}, {1, 0}}, 3, kmeans.PAM)
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+}
@@ -15,6 +15,26 @@ func distance(p, q Point) float64 {
 	return math.Sqrt(sum)
 }
 
+// dot calculates the dot product of two n-dimensional points p and q.
+// Formula: Σ(p_i * q_i for i=1..n)
+func dot(p, q Point) float64 {
+	sum := 0.0
+	for i := range p {
+		sum += p[i] * q[i]
+	}
+
+	return sum
+}
+
+// weightOf returns the sample weight of point i, 1 if no weights are set.
+func weightOf(weights []float64, i int) float64 {
+	if weights == nil {
+		return 1
+	}
+
+	return weights[i]
+}
+
 // mean computes the centroid (average point) of a group of points.
 // Each coordinate of the centroid is the average of the corresponding coordinates of the points.
 func mean(points []Point) Point {
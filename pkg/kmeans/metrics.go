@@ -0,0 +1,182 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// Metric measures the dissimilarity between two n-dimensional points.
+type Metric interface {
+	// Distance returns the dissimilarity between p and q; 0 means identical points.
+	Distance(p, q Point) float64
+	// MeanCentroid reports whether the arithmetic mean of a cluster is the point that
+	// minimises the total error under this metric, i.e. whether k-means updates are valid for it.
+	MeanCentroid() bool
+}
+
+// DistanceFunc adapts an ordinary function to the Metric interface.
+// Nothing is known about its relation to the mean, so MeanCentroid reports false.
+type DistanceFunc func(p, q Point) float64
+
+// Distance calls f(p, q).
+func (f DistanceFunc) Distance(p, q Point) float64 {
+	return f(p, q)
+}
+
+// MeanCentroid always reports false for a plain function.
+func (f DistanceFunc) MeanCentroid() bool {
+	return false
+}
+
+// Built-in metrics.
+var (
+	// Euclidean is the straight-line distance sqrt(Σ(p_i - q_i)^2). It is the default metric.
+	Euclidean Metric = euclideanMetric{}
+	// SquaredEuclidean is Σ(p_i - q_i)^2. It orders points like Euclidean but skips the square root.
+	SquaredEuclidean Metric = squaredEuclideanMetric{}
+	// Manhattan is the city-block distance Σ|p_i - q_i|. Its optimal centroid is the median.
+	Manhattan Metric = manhattanMetric{}
+	// Chebyshev is the largest coordinate difference max|p_i - q_i|.
+	Chebyshev Metric = chebyshevMetric{}
+	// Cosine is 1 - cos(p, q), the angular dissimilarity of the vectors.
+	// A zero vector has no direction and is at distance 1 from every point.
+	Cosine Metric = cosineMetric{}
+)
+
+// Minkowski returns the Minkowski distance (Σ|p_i - q_i|^p)^(1/p).
+// p = 1 gives Manhattan and p = 2 Euclidean; p must be at least 1 to define a metric.
+func Minkowski(p float64) Metric {
+	return minkowskiMetric{p: p}
+}
+
+// metricName returns a readable name of the metric for error messages.
+func metricName(m Metric) string {
+	if s, ok := m.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%T", m)
+}
+
+// squaredError returns the error a point contributes to SSE under the metric.
+// For the squared Euclidean metric the distance already is the squared error;
+// for the unit dot product it is converted to the squared Euclidean distance of the unit vectors.
+func squaredError(m Metric, p, q Point) float64 {
+	d := m.Distance(p, q)
+	switch m.(type) {
+	case squaredEuclideanMetric:
+		return d
+	case unitDotMetric:
+		return 2 * d // ||p - q||^2 = 2(1 - p·q) for unit vectors
+	}
+
+	return d * d
+}
+
+// euclideanScale returns a function converting distances of the metric to Euclidean distances,
+// or nil if the metric is not a monotone transformation of the Euclidean distance.
+// The triangle-inequality bounds of the accelerated algorithms are kept in Euclidean units.
+func euclideanScale(m Metric) func(float64) float64 {
+	switch metric := m.(type) {
+	case euclideanMetric:
+		return func(d float64) float64 { return d }
+	case squaredEuclideanMetric:
+		return math.Sqrt
+	case minkowskiMetric:
+		if metric.p == 2 {
+			return func(d float64) float64 { return d }
+		}
+	case unitDotMetric:
+		return func(d float64) float64 { return math.Sqrt(2 * max(d, 0)) }
+	}
+
+	return nil
+}
+
+type euclideanMetric struct{}
+
+func (euclideanMetric) Distance(p, q Point) float64 { return distance(p, q) }
+func (euclideanMetric) MeanCentroid() bool          { return true }
+func (euclideanMetric) String() string              { return "euclidean" }
+
+type squaredEuclideanMetric struct{}
+
+func (squaredEuclideanMetric) Distance(p, q Point) float64 {
+	sum := 0.0
+	for i := range p {
+		diff := p[i] - q[i]
+		sum += diff * diff
+	}
+
+	return sum
+}
+func (squaredEuclideanMetric) MeanCentroid() bool { return true }
+func (squaredEuclideanMetric) String() string     { return "squared euclidean" }
+
+type manhattanMetric struct{}
+
+func (manhattanMetric) Distance(p, q Point) float64 {
+	sum := 0.0
+	for i := range p {
+		sum += math.Abs(p[i] - q[i])
+	}
+
+	return sum
+}
+func (manhattanMetric) MeanCentroid() bool { return false }
+func (manhattanMetric) String() string     { return "manhattan" }
+
+type chebyshevMetric struct{}
+
+func (chebyshevMetric) Distance(p, q Point) float64 {
+	maxDiff := 0.0
+	for i := range p {
+		maxDiff = math.Max(maxDiff, math.Abs(p[i]-q[i]))
+	}
+
+	return maxDiff
+}
+func (chebyshevMetric) MeanCentroid() bool { return false }
+func (chebyshevMetric) String() string     { return "chebyshev" }
+
+type minkowskiMetric struct {
+	p float64
+}
+
+func (m minkowskiMetric) Distance(p, q Point) float64 {
+	sum := 0.0
+	for i := range p {
+		sum += math.Pow(math.Abs(p[i]-q[i]), m.p)
+	}
+
+	return math.Pow(sum, 1/m.p)
+}
+func (m minkowskiMetric) MeanCentroid() bool { return m.p == 2 }
+func (m minkowskiMetric) String() string     { return fmt.Sprintf("minkowski(p=%g)", m.p) }
+
+type cosineMetric struct{}
+
+func (cosineMetric) Distance(p, q Point) float64 {
+	dot, normP, normQ := 0.0, 0.0, 0.0
+	for i := range p {
+		dot += p[i] * q[i]
+		normP += p[i] * p[i]
+		normQ += q[i] * q[i]
+	}
+
+	if normP == 0 || normQ == 0 {
+		return 1 // no direction, no similarity
+	}
+
+	return 1 - dot/math.Sqrt(normP*normQ)
+}
+func (cosineMetric) MeanCentroid() bool { return false }
+func (cosineMetric) String() string     { return "cosine" }
+
+// unitDotMetric is 1 - p·q, the cosine distance of unit vectors without the norms.
+// It is only valid for points and centroids of unit length, see SphericalKMeans.
+type unitDotMetric struct{}
+
+func (unitDotMetric) Distance(p, q Point) float64 { return 1 - dot(p, q) }
+func (unitDotMetric) MeanCentroid() bool          { return false }
+func (unitDotMetric) String() string              { return "unit dot product" }
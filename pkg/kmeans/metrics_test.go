@@ -0,0 +1,101 @@
+package kmeans_test
+
+import (
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var metricTestCases = []struct {
+	name     string
+	metric   kmeans.Metric
+	p, q     kmeans.Point
+	expected float64
+}{
+	{"euclidean: 2D", kmeans.Euclidean, kmeans.Point{0, 0}, kmeans.Point{3, 4}, 5.0},
+	{"euclidean: same", kmeans.Euclidean, kmeans.Point{1, 2, 3}, kmeans.Point{1, 2, 3}, 0.0},
+
+	{"squared euclidean: 2D", kmeans.SquaredEuclidean, kmeans.Point{0, 0}, kmeans.Point{3, 4}, 25.0},
+	{"squared euclidean: 3D", kmeans.SquaredEuclidean, kmeans.Point{1, 1, 1}, kmeans.Point{4, 5, 6}, 50.0},
+
+	{"manhattan: 2D", kmeans.Manhattan, kmeans.Point{0, 0}, kmeans.Point{3, 4}, 7.0},
+	{"manhattan: negative", kmeans.Manhattan, kmeans.Point{-1, -1, -1}, kmeans.Point{1, 1, 1}, 6.0},
+
+	{"chebyshev: 2D", kmeans.Chebyshev, kmeans.Point{0, 0}, kmeans.Point{3, 4}, 4.0},
+	{"chebyshev: 5D", kmeans.Chebyshev, kmeans.Point{1, 2, 3, 4, 5}, kmeans.Point{5, 4, 3, 2, 1}, 4.0},
+
+	{"minkowski: p=1", kmeans.Minkowski(1), kmeans.Point{0, 0}, kmeans.Point{3, 4}, 7.0},
+	{"minkowski: p=2", kmeans.Minkowski(2), kmeans.Point{0, 0}, kmeans.Point{3, 4}, 5.0},
+	{"minkowski: p=3", kmeans.Minkowski(3), kmeans.Point{0, 0}, kmeans.Point{1, 1}, 1.2599210498948732},
+
+	{"cosine: same direction", kmeans.Cosine, kmeans.Point{1, 2}, kmeans.Point{2, 4}, 0.0},
+	{"cosine: orthogonal", kmeans.Cosine, kmeans.Point{1, 0}, kmeans.Point{0, 3}, 1.0},
+	{"cosine: opposite", kmeans.Cosine, kmeans.Point{1, 1}, kmeans.Point{-1, -1}, 2.0},
+	{"cosine: zero vector", kmeans.Cosine, kmeans.Point{0, 0}, kmeans.Point{1, 1}, 1.0},
+}
+
+func TestMetricDistance(t *testing.T) {
+	for _, testCase := range metricTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := testCase.metric.Distance(testCase.p, testCase.q)
+
+			assert.InDelta(t, testCase.expected, actual, 1e-9)
+		})
+	}
+}
+
+type MetricsSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
+
+func (s *MetricsSuite) TestMeanCentroid() {
+	s.True(kmeans.Euclidean.MeanCentroid())
+	s.True(kmeans.SquaredEuclidean.MeanCentroid())
+	s.True(kmeans.Minkowski(2).MeanCentroid())
+	s.False(kmeans.Minkowski(1).MeanCentroid())
+	s.False(kmeans.Manhattan.MeanCentroid())
+	s.False(kmeans.Chebyshev.MeanCentroid())
+	s.False(kmeans.Cosine.MeanCentroid())
+	s.False(kmeans.DistanceFunc(func(p, q kmeans.Point) float64 { return 0 }).MeanCentroid())
+}
+
+func (s *MetricsSuite) TestFitRejectsMetricWithoutMeanCentroid() {
+	_, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithMetric(kmeans.Manhattan))
+	s.ErrorIs(err, kmeans.ErrMetricNotMeanCompatible)
+	s.ErrorContains(err, "manhattan")
+}
+
+func (s *MetricsSuite) TestFitRejectsInvalidMinkowski() {
+	_, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithMetric(kmeans.Minkowski(0.5)))
+	s.ErrorIs(err, kmeans.ErrInvalidMetric)
+}
+
+func (s *MetricsSuite) TestFitWithSquaredEuclideanMatchesEuclidean() {
+	points := randomPoints(500, 3, 5)
+
+	euclidean, err := kmeans.Fit(points, kmeans.WithK(4), kmeans.WithSeed(9))
+	s.Require().NoError(err)
+	squared, err := kmeans.Fit(points, kmeans.WithK(4), kmeans.WithSeed(9), kmeans.WithMetric(kmeans.SquaredEuclidean))
+	s.Require().NoError(err)
+
+	s.Equal(euclidean.Assignments, squared.Assignments)
+	s.InDelta(euclidean.SSE, squared.SSE, 1e-6)
+}
+
+func (s *MetricsSuite) TestCalculateSSEWithMetric() {
+	points := []kmeans.Point{{0, 0}, {2, 2}}
+	centroids := []kmeans.Point{{1, 1}}
+	assignments := []int{0, 0}
+
+	s.InDelta(4.0, kmeans.CalculateSSEWithMetric(points, centroids, assignments, kmeans.Euclidean), 1e-9)
+	s.InDelta(4.0, kmeans.CalculateSSEWithMetric(points, centroids, assignments, kmeans.SquaredEuclidean), 1e-9)
+	s.InDelta(8.0, kmeans.CalculateSSEWithMetric(points, centroids, assignments, kmeans.Manhattan), 1e-9)
+	s.InDelta(2.0, kmeans.CalculateSSEWithMetric(points, centroids, assignments, kmeans.Chebyshev), 1e-9)
+	s.InDelta(4.0, kmeans.CalculateMSEWithMetric(points, centroids, assignments, kmeans.Manhattan), 1e-9)
+}
@@ -0,0 +1,153 @@
+package kmeans
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+// MiniBatchKMeans performs mini-batch k-means clustering (Sculley, "Web-Scale K-Means Clustering").
+// Each iteration samples WithBatchSize points with replacement, assigns them to their nearest
+// centroids and moves every centroid towards its samples with a per-centroid learning rate
+// of 1/count, where count is the number of samples the centroid has absorbed so far.
+//
+// It accepts the same options as Fit and is equivalent to Fit with WithAlgorithm(MiniBatch).
+// After the last iteration one full assignment pass labels every point, so the Result has the
+// same meaning as for Lloyd's algorithm.
+func MiniBatchKMeans(points []Point, opts ...Option) (*Result, error) {
+	return Fit(points, append(slices.Clone(opts), WithAlgorithm(MiniBatch))...)
+}
+
+// miniBatch runs mini-batch iterations until one of the stop criteria is met or the context is done.
+//
+// Assignments are not tracked between batches, so the run never stops on StopAssignmentsStable.
+// With sample weights the batches are drawn proportionally to weight, so the unweighted updates
+// still converge to weighted means.
+// Instead of the SSE improvement test, which a single noisy batch would trip, the run counts the
+// batches since the exponentially weighted average of the per-point batch error (smoothed with
+// weight batchSize/n) last reached a new minimum, and stops after WithMaxNoImprovement of them.
+func miniBatch(ctx context.Context, points []Point, cfg *config) (*Result, error) {
+	centroids := clonePoints(cfg.initializer(points, cfg.k, cfg.initConfig()))
+	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = -1 // points are only labelled by the final full pass
+	}
+
+	batch := make([]int, min(cfg.batchSize, len(points)))
+	nearest := make([]int, len(batch))
+	counts := make([]int, cfg.k) // number of samples absorbed by each centroid
+	alpha := float64(len(batch)) / float64(len(points))
+	smoothedError := -1.0
+	bestError := math.Inf(1)
+	stale := 0 // batches since smoothedError last improved on bestError
+	cumulative := cumulativeWeights(cfg.weights)
+
+	result := &Result{Centroids: centroids}
+	check := newConvergenceCheck(cfg)
+	check.sseTolerance = 0 // replaced by the no-improvement test
+	for result.Iterations < cfg.maxIterations {
+		if err := ctx.Err(); err != nil {
+			return interrupted(result, points, assignments, cfg), err
+		}
+
+		batchError := 0.0
+		for i := range batch {
+			batch[i] = samplePoint(cfg.rng, len(points), cumulative)
+			nearest[i] = nearestCentroid(points[batch[i]], centroids, cfg.metric)
+			batchError += squaredError(cfg.metric, points[batch[i]], centroids[nearest[i]])
+		}
+		batchError /= float64(len(batch))
+
+		if smoothedError < 0 {
+			smoothedError = batchError
+		} else {
+			smoothedError = (1-alpha)*smoothedError + alpha*batchError
+		}
+
+		shift := miniBatchUpdate(points, centroids, counts, batch, nearest)
+		result.Iterations++
+
+		if smoothedError < bestError {
+			bestError, stale = smoothedError, 0
+		} else {
+			stale++
+		}
+
+		reason, stop := check.stop(true, shift, nil)
+		if !stop && cfg.noImprovement > 0 && stale >= cfg.noImprovement {
+			reason, stop = StopNoImprovement, true
+		}
+		if stop {
+			result.StopReason = reason
+			result.Converged = true
+			break
+		}
+	}
+
+	if _, err := assignPoints(ctx, points, centroids, assignments, cfg); err != nil {
+		return interrupted(result, points, assignments, cfg), err
+	}
+	finalize(result, points, assignments, cfg)
+
+	return result, nil
+}
+
+// miniBatchUpdate moves the centroids towards the sampled points using per-centroid learning rates:
+//
+//	count_c = count_c + 1
+//	c = (1 - 1/count_c) * c + (1/count_c) * x
+//
+// It returns the largest distance any centroid moved during the batch.
+func miniBatchUpdate(points, centroids []Point, counts, batch, nearest []int) float64 {
+	previous := make(map[int]Point)
+
+	for i, index := range batch {
+		c := nearest[i]
+		if _, ok := previous[c]; !ok {
+			previous[c] = append(Point(nil), centroids[c]...)
+		}
+
+		counts[c]++
+		eta := 1 / float64(counts[c]) // per-centroid learning rate
+		for d, value := range points[index] {
+			centroids[c][d] = (1-eta)*centroids[c][d] + eta*value
+		}
+	}
+
+	maxShift := 0.0
+	for c, old := range previous {
+		maxShift = max(maxShift, distance(old, centroids[c]))
+	}
+
+	return maxShift
+}
+
+// cumulativeWeights returns the running sums of the weights, or nil without weights.
+func cumulativeWeights(weights []float64) []float64 {
+	if weights == nil {
+		return nil
+	}
+
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		total += w
+		cumulative[i] = total
+	}
+
+	return cumulative
+}
+
+// samplePoint draws a point index uniformly, or proportionally to its weight given the
+// cumulative weights. Points of zero weight are never drawn.
+func samplePoint(rng *rand.Rand, n int, cumulative []float64) int {
+	if cumulative == nil {
+		return rng.Intn(n)
+	}
+
+	target := rng.Float64() * cumulative[n-1]
+
+	return sort.Search(n, func(i int) bool { return cumulative[i] > target })
+}
@@ -0,0 +1,133 @@
+package kmeans_test
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiniBatchSuite struct {
+	suite.Suite
+}
+
+func TestMiniBatchSuite(t *testing.T) {
+	suite.Run(t, new(MiniBatchSuite))
+}
+
+// gaussianBlobs returns perCenter normally distributed points around each center.
+func gaussianBlobs(centers []kmeans.Point, perCenter int, spread float64, seed int64) []kmeans.Point {
+	rng := rand.New(rand.NewSource(seed))
+	points := make([]kmeans.Point, 0, len(centers)*perCenter)
+	for _, center := range centers {
+		for range perCenter {
+			p := make(kmeans.Point, len(center))
+			for d := range p {
+				p[d] = center[d] + rng.NormFloat64()*spread
+			}
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// sortedByFirstCoordinate returns the points ordered by their first coordinate.
+func sortedByFirstCoordinate(points []kmeans.Point) []kmeans.Point {
+	sorted := append([]kmeans.Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+	return sorted
+}
+
+func (s *MiniBatchSuite) TestFindsBlobCenters() {
+	centers := []kmeans.Point{{0, 0}, {20, 20}, {40, 0}}
+	points := gaussianBlobs(centers, 1000, 1, 11)
+
+	result, err := kmeans.MiniBatchKMeans(points,
+		kmeans.WithK(3), kmeans.WithSeed(5), kmeans.WithBatchSize(100), kmeans.WithMaxIterations(200))
+	s.Require().NoError(err)
+
+	s.Equal(200, result.Iterations)
+	s.Len(result.Assignments, len(points))
+	for i, c := range sortedByFirstCoordinate(result.Centroids) {
+		s.InDelta(centers[i][0], c[0], 0.5)
+		s.InDelta(centers[i][1], c[1], 0.5)
+	}
+	s.Equal([]int{1000, 1000, 1000}, result.ClusterSizes)
+}
+
+func (s *MiniBatchSuite) TestReproducibleWithSeed() {
+	points := randomPoints(3000, 4, 2)
+	opts := []kmeans.Option{kmeans.WithK(5), kmeans.WithSeed(8), kmeans.WithBatchSize(64), kmeans.WithMaxIterations(50)}
+
+	first, err := kmeans.MiniBatchKMeans(points, opts...)
+	s.Require().NoError(err)
+	second, err := kmeans.Fit(points, append(opts, kmeans.WithAlgorithm(kmeans.MiniBatch))...)
+	s.Require().NoError(err)
+
+	s.Equal(first.Centroids, second.Centroids)
+	s.Equal(first.Assignments, second.Assignments)
+}
+
+func (s *MiniBatchSuite) TestStopsOnCentroidShift() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {20, 20}}, 1000, 1, 3)
+
+	result, err := kmeans.MiniBatchKMeans(points,
+		kmeans.WithK(2), kmeans.WithSeed(1), kmeans.WithBatchSize(100), kmeans.WithTolerance(0.05))
+	s.Require().NoError(err)
+
+	s.True(result.Converged)
+	s.Equal(kmeans.StopCentroidShift, result.StopReason)
+	s.Less(result.Iterations, 300)
+}
+
+func (s *MiniBatchSuite) TestStopsWithoutImprovementOnLargeInput() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {10, 0}, {0, 10}, {10, 10}, {5, 5}}, 20000, 2, 1)
+
+	lloyd, err := kmeans.Fit(points, kmeans.WithK(5), kmeans.WithSeed(1))
+	s.Require().NoError(err)
+	result, err := kmeans.MiniBatchKMeans(points, kmeans.WithK(5), kmeans.WithSeed(0), kmeans.WithBatchSize(256),
+		kmeans.WithMaxNoImprovement(10), kmeans.WithMaxIterations(5000))
+	s.Require().NoError(err)
+
+	s.Equal(kmeans.StopNoImprovement, result.StopReason)
+	s.Greater(result.Iterations, 100) // far more batches than the smoothing weight 256/n allows to settle
+	s.Less(result.Iterations, 5000)
+	s.InDelta(1, result.SSE/lloyd.SSE, 0.01)
+
+	// The per-iteration SSE test does not apply to mini-batches
+	loose, err := kmeans.MiniBatchKMeans(points, kmeans.WithK(5), kmeans.WithSeed(0), kmeans.WithBatchSize(256),
+		kmeans.WithSSETolerance(1e-2), kmeans.WithMaxIterations(50))
+	s.Require().NoError(err)
+	s.Equal(50, loose.Iterations)
+}
+
+func (s *MiniBatchSuite) TestCancelledRunReturnsCentroids() {
+	ctx := &expiringContext{Context: context.Background(), remaining: 3}
+
+	result, err := kmeans.FitContext(ctx, randomPoints(500, 2, 4),
+		kmeans.WithK(3), kmeans.WithSeed(1), kmeans.WithAlgorithm(kmeans.MiniBatch))
+	s.ErrorIs(err, context.Canceled)
+	s.Require().NotNil(result)
+
+	s.Equal(kmeans.StopCancelled, result.StopReason)
+	s.Equal(3, result.Iterations)
+	s.Len(result.Centroids, 3)
+	s.Nil(result.Assignments)
+}
+
+func (s *MiniBatchSuite) TestInvalidOptionsReturnError() {
+	_, err := kmeans.MiniBatchKMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithBatchSize(0))
+	s.ErrorIs(err, kmeans.ErrInvalidBatchSize)
+
+	_, err = kmeans.MiniBatchKMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithMaxNoImprovement(-1))
+	s.ErrorIs(err, kmeans.ErrInvalidNoImprovement)
+
+	_, err = kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithAlgorithm(kmeans.Algorithm(99)))
+	s.ErrorIs(err, kmeans.ErrUnknownAlgorithm)
+
+	_, err = kmeans.MiniBatchKMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithMetric(kmeans.Cosine))
+	s.ErrorIs(err, kmeans.ErrMetricNotMeanCompatible)
+}
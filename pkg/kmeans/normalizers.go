@@ -1,5 +1,7 @@
 package kmeans
 
+import "math"
+
 // NormalizePoints applies min-max normalization to a set of points.
 // Each coordinate of the point is scaled to the range [0, 1] using the formula:
 //
@@ -27,3 +29,21 @@ func NormalizePoints(points []Point) []Point {
 
 	return normalized
 }
+
+// NormalizeUnitLength scales every point to unit Euclidean (L2) length, so that the dot product
+// of two normalized points is their cosine similarity. Zero vectors have no direction and are kept as they are.
+//
+// Formula: normalized = x / sqrt(Σ x_i^2)
+func NormalizeUnitLength(points []Point) []Point {
+	normalized := make([]Point, len(points))
+	for i, point := range points {
+		normalized[i] = append(Point(nil), point...)
+		if norm := math.Sqrt(dot(point, point)); norm > 0 {
+			for j := range normalized[i] {
+				normalized[i][j] /= norm
+			}
+		}
+	}
+
+	return normalized
+}
@@ -0,0 +1,491 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+)
+
+const (
+	// defaultMaxIterations is the iteration cap used when WithMaxIterations is not given.
+	defaultMaxIterations = 300
+	// defaultBatchSize is the mini-batch size used when WithBatchSize is not given.
+	defaultBatchSize = 1024
+	// defaultGapReferences is the number of reference datasets used when WithGapReferences is not given.
+	defaultGapReferences = 10
+	// defaultSignificance is the significance level of the G-means normality test, as in the original paper.
+	defaultSignificance = 0.0001
+	// defaultSamples is the number of CLARA samples used when WithSamples is not given, as in the original.
+	defaultSamples = 5
+	// defaultFuzzifier is the fuzzifier of FuzzyCMeans used when WithFuzzifier is not given.
+	defaultFuzzifier = 2
+	// defaultEpsilon is the membership change FuzzyCMeans stops below when WithEpsilon is not given.
+	defaultEpsilon = 1e-5
+	// defaultLocalSearches is the number of CLARANS searches used when WithLocalSearches is not given.
+	defaultLocalSearches = 2
+)
+
+// Algorithm selects the iteration scheme used by Fit.
+type Algorithm int
+
+const (
+	// Lloyd is the classic full-batch k-means: assign every point, then move centroids to the means.
+	Lloyd Algorithm = iota
+	// MiniBatch updates centroids from small random samples, see MiniBatchKMeans.
+	MiniBatch
+	// Elkan gives the same result as Lloyd, but skips most distance computations using the
+	// triangle inequality with one upper and k lower bounds per point. Best for high k.
+	Elkan
+	// Hamerly gives the same result as Lloyd, keeping a single lower bound per point.
+	// It needs far less memory than Elkan and is usually faster in low dimensions.
+	Hamerly
+)
+
+// String returns a human-readable name of the algorithm.
+func (a Algorithm) String() string {
+	switch a {
+	case Lloyd:
+		return "lloyd"
+	case MiniBatch:
+		return "mini-batch"
+	case Elkan:
+		return "elkan"
+	case Hamerly:
+		return "hamerly"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", int(a))
+	}
+}
+
+// Option configures a clustering run started with Fit.
+type Option func(*config)
+
+// config holds every parameter of a single clustering run.
+// It is built from the default values and the options passed to Fit.
+type config struct {
+	k             int
+	maxIterations int
+	initializer   SeededInitializer
+	rng           *rand.Rand
+	tolerance     float64
+	sseTolerance  float64
+	workers       int
+	metric        Metric
+	algorithm     Algorithm
+	batchSize     int
+	noImprovement int // batches without a better smoothed error before MiniBatch stops, 0 disables the test
+	restarts      int
+	parallelRuns  bool
+	emptyClusters EmptyClusterStrategy
+	parallelSweep bool
+	gapReferences int
+	significance  float64
+	samples       int
+	sampleSize    int
+	localSearches int
+	maxNeighbors  int
+	medians       bool // update centroids with the coordinate-wise median, set by KMedians
+	fuzzifier     float64
+	epsilon       float64
+	prenormalized bool
+	weights       []float64
+	spherical     bool // project centroids onto the unit sphere after the update, set by SphericalKMeans
+	constrained   bool // assign points by minimum-cost flow within minSize and maxSize, set by SizeConstrainedKMeans
+	minSize       int
+	maxSize       int
+}
+
+// newConfig returns the default configuration with all options applied in order.
+func newConfig(opts []Option) config {
+	cfg := config{
+		maxIterations: defaultMaxIterations,
+		initializer:   SeededSmartCentroids,
+		workers:       runtime.GOMAXPROCS(0),
+		metric:        Euclidean,
+		algorithm:     Lloyd,
+		batchSize:     defaultBatchSize,
+		restarts:      1,
+		gapReferences: defaultGapReferences,
+		significance:  defaultSignificance,
+		samples:       defaultSamples,
+		localSearches: defaultLocalSearches,
+		fuzzifier:     defaultFuzzifier,
+		epsilon:       defaultEpsilon,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.rng == nil {
+		cfg.rng = unseededRand()
+	}
+
+	return cfg
+}
+
+// validate checks the options of Fit, and of the modes built on it, together with the dataset.
+// Options of other entry points are checked by them, so a value Fit ignores is never an error.
+func (c *config) validate(points []Point) error {
+	if err := c.validateBase(points); err != nil {
+		return err
+	}
+
+	if c.tolerance < 0 || c.sseTolerance < 0 || math.IsNaN(c.tolerance) || math.IsNaN(c.sseTolerance) {
+		return ErrInvalidTolerance
+	}
+
+	if err := c.validateAlgorithm(); err != nil {
+		return err
+	}
+
+	if c.restarts <= 0 {
+		return ErrInvalidNumberOfRestarts
+	}
+
+	if c.emptyClusters < KeepCentroid || c.emptyClusters > FailOnEmptyCluster {
+		return fmt.Errorf("%w: %s", ErrUnknownEmptyClusterStrategy, c.emptyClusters)
+	}
+
+	return nil
+}
+
+// validateBase checks the dataset and the options every point-based entry point uses.
+func (c *config) validateBase(points []Point) error {
+	if err := ValidateWeightedPoints(points, c.weights, c.k); err != nil {
+		return err
+	}
+
+	if c.maxIterations <= 0 {
+		return ErrInvalidNumberOfIterations
+	}
+
+	if c.workers <= 0 {
+		return ErrInvalidNumberOfWorkers
+	}
+
+	if m, ok := c.metric.(minkowskiMetric); ok && !(m.p >= 1) {
+		return fmt.Errorf("%w: %s", ErrInvalidMetric, metricName(m))
+	}
+
+	return nil
+}
+
+// validateAlgorithm checks the algorithm and the options specific to it.
+func (c *config) validateAlgorithm() error {
+	switch c.algorithm {
+	case Lloyd:
+	case MiniBatch:
+		if c.batchSize <= 0 {
+			return ErrInvalidBatchSize
+		}
+
+		if c.noImprovement < 0 {
+			return ErrInvalidNoImprovement
+		}
+	case Elkan, Hamerly:
+		if euclideanScale(c.metric) == nil {
+			return fmt.Errorf("%w: %s requires a Euclidean metric, got %s",
+				ErrMetricNotSupported, c.algorithm, metricName(c.metric))
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownAlgorithm, c.algorithm)
+	}
+
+	return nil
+}
+
+// validateSignificance checks the significance level of the G-means normality test.
+func (c *config) validateSignificance() error {
+	if !(c.significance > 0 && c.significance < 1) {
+		return ErrInvalidSignificance
+	}
+
+	return nil
+}
+
+// validateFuzzy checks the fuzzifier and the membership tolerance of FuzzyCMeans.
+func (c *config) validateFuzzy() error {
+	if !(c.fuzzifier > 1) || math.IsInf(c.fuzzifier, 1) {
+		return ErrInvalidFuzzifier
+	}
+
+	if !(c.epsilon >= 0) {
+		return ErrInvalidTolerance
+	}
+
+	return nil
+}
+
+// validateSampling checks the parameters of CLARA and CLARANS.
+func (c *config) validateSampling() error {
+	if c.samples <= 0 {
+		return ErrInvalidNumberOfSamples
+	}
+
+	if c.sampleSize != 0 && c.sampleSize < c.k {
+		return fmt.Errorf("%w: %d < %d", ErrSampleTooSmall, c.sampleSize, c.k)
+	}
+
+	if c.localSearches <= 0 {
+		return ErrInvalidNumberOfSearches
+	}
+
+	if c.maxNeighbors < 0 {
+		return ErrInvalidNumberOfNeighbors
+	}
+
+	return nil
+}
+
+// rejectWeights returns ErrWeightsNotSupported if sample weights are set for an algorithm that would ignore them.
+func (c *config) rejectWeights(algorithm string) error {
+	if c.weights != nil {
+		return fmt.Errorf("%w: %s", ErrWeightsNotSupported, algorithm)
+	}
+
+	return nil
+}
+
+// initConfig returns the state handed to the initializer of the run.
+func (c *config) initConfig() InitConfig {
+	return InitConfig{Rand: c.rng, Metric: c.metric, Workers: c.workers, Weights: c.weights}
+}
+
+// WithK sets the number of clusters to form. It is required.
+func WithK(k int) Option {
+	return func(c *config) {
+		c.k = k
+	}
+}
+
+// WithMaxIterations sets the maximum number of assignment/update passes.
+// Defaults to 300.
+func WithMaxIterations(iterations int) Option {
+	return func(c *config) {
+		c.maxIterations = iterations
+	}
+}
+
+// WithInitializer sets the function used to pick the starting centroids.
+// Defaults to SmartCentroids (k-means++). A nil function keeps the default.
+// The function cannot see the seed of the run; use WithSeededInitializer for reproducible runs.
+func WithInitializer(initializeCentroids InitializeCentroidsFunction) Option {
+	return func(c *config) {
+		if initializeCentroids != nil {
+			c.initializer = func(points []Point, k int, _ InitConfig) []Point {
+				return initializeCentroids(points, k)
+			}
+		}
+	}
+}
+
+// WithSeededInitializer sets an initializer that draws its randomness from the run's source,
+// e.g. SeededRandomCentroids or SeededSmartCentroids. A nil function keeps the default.
+func WithSeededInitializer(initializer SeededInitializer) Option {
+	return func(c *config) {
+		if initializer != nil {
+			c.initializer = initializer
+		}
+	}
+}
+
+// WithSeed makes the run reproducible: the same seed, data and options
+// always give bit-identical centroids and assignments.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		// #nosec G404 -- Deterministic source requested by the caller
+		c.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithRand sets the source of randomness of the run.
+// The generator is not safe for concurrent use, so it must not be shared between concurrent runs.
+func WithRand(rng *rand.Rand) Option {
+	return func(c *config) {
+		c.rng = rng
+	}
+}
+
+// WithTolerance stops the run once the largest centroid movement of an update is less than tolerance.
+// Defaults to 0, i.e. the run only stops early when no assignment changes.
+func WithTolerance(tolerance float64) Option {
+	return func(c *config) {
+		c.tolerance = tolerance
+	}
+}
+
+// WithSSETolerance stops the run once the relative SSE improvement of an iteration,
+// (SSE_prev - SSE) / SSE_prev, is not greater than threshold.
+// Defaults to 0, which disables the test and avoids computing the SSE on every iteration.
+// MiniBatch ignores it: a batch error is too noisy for a per-iteration test, use WithMaxNoImprovement.
+func WithSSETolerance(threshold float64) Option {
+	return func(c *config) {
+		c.sseTolerance = threshold
+	}
+}
+
+// WithWorkers sets how many goroutines share the assignment and update steps.
+// Defaults to GOMAXPROCS. The result does not depend on the number of workers.
+// The update steps of FuzzyCMeans and KMedians are split by dimension, so they use at most
+// one goroutine per coordinate.
+func WithWorkers(workers int) Option {
+	return func(c *config) {
+		c.workers = workers
+	}
+}
+
+// WithMetric sets the distance used to assign points, seed the initial centroids and compute the SSE.
+// Defaults to Euclidean. A nil metric keeps the default.
+// Fit only accepts metrics whose MeanCentroid reports true, since it updates centroids with the mean.
+func WithMetric(metric Metric) Option {
+	return func(c *config) {
+		if metric != nil {
+			c.metric = metric
+		}
+	}
+}
+
+// WithAlgorithm selects the iteration scheme. Defaults to Lloyd.
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(c *config) {
+		c.algorithm = algorithm
+	}
+}
+
+// WithBatchSize sets the number of points sampled per mini-batch iteration.
+// Defaults to 1024; it is capped at the number of points. Only used by MiniBatch.
+func WithBatchSize(batchSize int) Option {
+	return func(c *config) {
+		c.batchSize = batchSize
+	}
+}
+
+// WithMaxNoImprovement stops MiniBatch runs after the given number of consecutive batches that
+// do not lower the smoothed batch error below its best value so far, like max_no_improvement
+// in scikit-learn (which uses 10). The run then ends with StopNoImprovement.
+// Defaults to 0, which disables the test. Only used by MiniBatch.
+func WithMaxNoImprovement(batches int) Option {
+	return func(c *config) {
+		c.noImprovement = batches
+	}
+}
+
+// WithRestarts runs the clustering n times from independent initializations and keeps
+// the solution with the lowest SSE (the first one on ties). Result.RestartSSE lists the SSE
+// of every restart. Each restart gets its own seed drawn from the source of the run,
+// so WithSeed makes the whole set of restarts reproducible. Defaults to 1.
+func WithRestarts(n int) Option {
+	return func(c *config) {
+		c.restarts = n
+	}
+}
+
+// WithParallelRestarts runs the restarts concurrently. The result is the same as
+// running them one after another; each restart still uses WithWorkers goroutines.
+func WithParallelRestarts() Option {
+	return func(c *config) {
+		c.parallelRuns = true
+	}
+}
+
+// WithEmptyClusterStrategy sets what happens when an assignment pass leaves a cluster without points.
+// Defaults to KeepCentroid. It applies to Lloyd, Elkan and Hamerly; MiniBatch keeps the centroid.
+func WithEmptyClusterStrategy(strategy EmptyClusterStrategy) Option {
+	return func(c *config) {
+		c.emptyClusters = strategy
+	}
+}
+
+// WithParallelSweep makes ChooseK cluster the values of k concurrently. The result is the same
+// as evaluating them one after another; each run still uses WithWorkers goroutines.
+func WithParallelSweep() Option {
+	return func(c *config) {
+		c.parallelSweep = true
+	}
+}
+
+// WithGapReferences sets how many uniform reference datasets the gap statistic of ChooseK
+// clusters for every k. Defaults to 10.
+func WithGapReferences(b int) Option {
+	return func(c *config) {
+		c.gapReferences = b
+	}
+}
+
+// WithSignificance sets the significance level of the Anderson–Darling test of GMeans:
+// a cluster is split when the p-value of its projection is below alpha. Lower values split less.
+// Defaults to 0.0001.
+func WithSignificance(alpha float64) Option {
+	return func(c *config) {
+		c.significance = alpha
+	}
+}
+
+// WithFuzzifier sets the fuzzifier m > 1 of FuzzyCMeans. Values close to 1 give nearly hard
+// memberships; larger values make them fuzzier. Defaults to 2.
+func WithFuzzifier(m float64) Option {
+	return func(c *config) {
+		c.fuzzifier = m
+	}
+}
+
+// WithEpsilon stops FuzzyCMeans once no membership changes by more than epsilon in an iteration.
+// Defaults to 1e-5; 0 runs until the memberships stop changing or the iteration cap is reached.
+func WithEpsilon(epsilon float64) Option {
+	return func(c *config) {
+		c.epsilon = epsilon
+	}
+}
+
+// WithSamples sets how many random samples CLARA clusters with PAM. Defaults to 5.
+func WithSamples(samples int) Option {
+	return func(c *config) {
+		c.samples = samples
+	}
+}
+
+// WithSampleSize sets the number of objects of every CLARA sample. It must be at least k;
+// it is capped at the number of objects. Defaults to 0, which selects 40+2k as in the original.
+func WithSampleSize(size int) Option {
+	return func(c *config) {
+		c.sampleSize = size
+	}
+}
+
+// WithLocalSearches sets how many times CLARANS restarts its search from random medoids. Defaults to 2.
+func WithLocalSearches(searches int) Option {
+	return func(c *config) {
+		c.localSearches = searches
+	}
+}
+
+// WithMaxNeighbors sets how many consecutive random swaps CLARANS tries without improvement before
+// it ends a search. Defaults to 0, which selects the larger of 250 and 1.25% of k(n-k) as in the original.
+func WithMaxNeighbors(neighbors int) Option {
+	return func(c *config) {
+		c.maxNeighbors = neighbors
+	}
+}
+
+// WithPrenormalized tells SphericalKMeans that the points already have unit length: they are
+// validated instead of normalized into a copy, which saves memory for large embedding sets.
+func WithPrenormalized() Option {
+	return func(c *config) {
+		c.prenormalized = true
+	}
+}
+
+// WithWeights gives every point a sample weight, e.g. the number of observations a pre-aggregated
+// row stands for: a point of weight w counts like w copies of it. Centroids are weighted means,
+// k-means++ samples proportionally to weight × D², mini-batches sample points proportionally to
+// their weight, and Result.SSE weights every point's error. Result.ClusterSizes still counts points.
+// Weights must be non-negative and finite with a positive sum; nil means unit weights (the default).
+// Fit, MiniBatchKMeans, SphericalKMeans and ChooseK with Elbow accept weights; the other
+// algorithms return ErrWeightsNotSupported.
+func WithWeights(weights []float64) Option {
+	return func(c *config) {
+		c.weights = weights
+	}
+}
@@ -0,0 +1,174 @@
+package kmeans
+
+import (
+	"context"
+	"sync"
+)
+
+// minPointsPerWorker is the smallest share of points worth handing to a separate goroutine.
+// Below it the cost of starting goroutines outweighs the work they do.
+const minPointsPerWorker = 1024
+
+// minPairsPerWorker is the smallest number of pairwise distances worth handing to a separate
+// goroutine in the quadratic passes, where every row already costs one distance per point.
+const minPairsPerWorker = 1 << 16
+
+// span is a half-open index range [lo, hi).
+type span struct {
+	lo, hi int
+}
+
+// splitRange divides n items into at most parts contiguous, nearly equal spans.
+func splitRange(n, parts int) []span {
+	parts = max(1, min(parts, n))
+	spans := make([]span, parts)
+
+	for i := range spans {
+		spans[i] = span{lo: i * n / parts, hi: (i + 1) * n / parts}
+	}
+
+	return spans
+}
+
+// effectiveWorkers limits the number of goroutines so that each one gets enough points.
+func effectiveWorkers(nPoints, workers int) int {
+	return max(1, min(workers, (nPoints+minPointsPerWorker-1)/minPointsPerWorker))
+}
+
+// runSpans calls fn for every span, in separate goroutines when there is more than one.
+func runSpans(spans []span, fn func(worker int, s span)) {
+	if len(spans) == 1 {
+		fn(0, spans[0])
+		return
+	}
+
+	var wg sync.WaitGroup
+	for w, s := range spans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(w, s)
+		}()
+	}
+	wg.Wait()
+}
+
+// assignPoints assigns each point to the nearest centroid under the run's metric, splitting
+// the points into contiguous ranges handled by up to workers goroutines.
+// It reports whether any assignment differs from the previous one.
+// For large inputs the context is checked every contextCheckInterval points;
+// an interrupted pass leaves some points with their previous assignment.
+func assignPoints(ctx context.Context, points, centroids []Point, assignments []int, cfg *config) (bool, error) {
+	return parallelPass(ctx, len(points), cfg.workers, func(i int) bool {
+		closestIndex := nearestCentroid(points[i], centroids, cfg.metric)
+		if assignments[i] == closestIndex {
+			return false
+		}
+
+		assignments[i] = closestIndex
+		return true
+	})
+}
+
+// parallelPass calls visit for every index in [0, n), splitting the range into contiguous
+// spans handled by up to workers goroutines. visit must only touch state of its own index.
+// It reports whether visit returned true for any index. The context is checked every
+// contextCheckInterval indices of a span; an interrupted pass leaves the remaining indices unvisited.
+func parallelPass(ctx context.Context, n, workers int, visit func(i int) bool) (bool, error) {
+	spans := splitRange(n, effectiveWorkers(n, workers))
+	changed := make([]bool, len(spans))
+	errs := make([]error, len(spans))
+
+	runSpans(spans, func(w int, s span) {
+		for i := s.lo; i < s.hi; i++ {
+			if i > s.lo && (i-s.lo)%contextCheckInterval == 0 {
+				if errs[w] = ctx.Err(); errs[w] != nil {
+					return
+				}
+			}
+
+			if visit(i) {
+				changed[w] = true
+			}
+		}
+	})
+
+	anyChanged := false
+	for w := range spans {
+		if errs[w] != nil {
+			return anyChanged, errs[w]
+		}
+		anyChanged = anyChanged || changed[w]
+	}
+
+	return anyChanged, nil
+}
+
+// parallelRows calls visit for every row in [0, n) of a pass over all pairs of n items,
+// using up to workers goroutines. The rows are dealt out in turn (w, w+W, w+2W, …) rather
+// than in contiguous spans, so the goroutines stay balanced when a row only visits the pairs
+// after it. visit must only touch state of its own row.
+func parallelRows(n, workers int, visit func(i int)) {
+	workers = max(1, min(workers, n, n*n/minPairsPerWorker))
+
+	runSpans(splitRange(workers, workers), func(w int, _ span) {
+		for i := w; i < n; i += workers {
+			visit(i)
+		}
+	})
+}
+
+// maxSumChunks caps the number of partial sums clusterSums keeps, and with it the number of
+// goroutines that share the update step.
+const maxSumChunks = 64
+
+// clusterSums adds up the points of every cluster, multiplied by their weights, and the
+// weights themselves; without weights the totals are the numbers of points.
+//
+// The points are cut into chunks of at least minPointsPerWorker points, at most maxSumChunks
+// of them, and each chunk is summed on its own by one of the workers. The chunk boundaries
+// depend only on the number of points, and the partial sums are merged in chunk order, so
+// the result is bit-identical for any number of workers. Up to minPointsPerWorker points
+// form a single chunk and are summed exactly like a serial pass.
+func clusterSums(points []Point, assignments []int, weights []float64, k, workers int) ([]Point, []float64) {
+	totals := make([]float64, k)
+	for i, a := range assignments {
+		totals[a] += weightOf(weights, i)
+	}
+
+	chunks := splitRange(len(points), min(maxSumChunks, (len(points)+minPointsPerWorker-1)/minPointsPerWorker))
+	partial := make([][]Point, len(chunks))
+	runSpans(splitRange(len(chunks), workers), func(_ int, s span) {
+		for c := s.lo; c < s.hi; c++ {
+			partial[c] = chunkSums(points, assignments, weights, k, chunks[c])
+		}
+	})
+
+	sums := partial[0]
+	for _, part := range partial[1:] {
+		for j, sum := range sums {
+			for d := range sum {
+				sum[d] += part[j][d]
+			}
+		}
+	}
+
+	return sums, totals
+}
+
+// chunkSums adds up the weighted points of every cluster within one chunk of the points.
+func chunkSums(points []Point, assignments []int, weights []float64, k int, chunk span) []Point {
+	sums := make([]Point, k)
+	for j := range sums {
+		sums[j] = make(Point, len(points[0]))
+	}
+
+	for i := chunk.lo; i < chunk.hi; i++ {
+		sum, w := sums[assignments[i]], weightOf(weights, i)
+		for d, x := range points[i] {
+			sum[d] += w * x
+		}
+	}
+
+	return sums
+}
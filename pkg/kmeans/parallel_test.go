@@ -0,0 +1,73 @@
+package kmeans_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type ParallelSuite struct {
+	suite.Suite
+}
+
+func TestParallelSuite(t *testing.T) {
+	suite.Run(t, new(ParallelSuite))
+}
+
+// randomPoints returns n reproducible points with coordinates drawn uniformly from [0, 100).
+func randomPoints(n, dim int, seed int64) []kmeans.Point {
+	rng := rand.New(rand.NewSource(seed))
+	points := make([]kmeans.Point, n)
+	for i := range points {
+		points[i] = make(kmeans.Point, dim)
+		for d := range points[i] {
+			points[i][d] = rng.Float64() * 100
+		}
+	}
+	return points
+}
+
+func (s *ParallelSuite) TestParallelMatchesSerial() {
+	points := randomPoints(5000, 5, 1)
+	opts := []kmeans.Option{kmeans.WithK(8), kmeans.WithSeed(3), kmeans.WithMaxIterations(20)}
+
+	serial, err := kmeans.Fit(points, append(opts, kmeans.WithWorkers(1))...)
+	s.Require().NoError(err)
+
+	for _, workers := range []int{2, 3, 64} {
+		parallel, err := kmeans.Fit(points, append(opts, kmeans.WithWorkers(workers))...)
+		s.Require().NoError(err)
+
+		s.Equal(serial.Centroids, parallel.Centroids, "workers: %d", workers)
+		s.Equal(serial.Assignments, parallel.Assignments, "workers: %d", workers)
+		s.Equal(serial.Iterations, parallel.Iterations, "workers: %d", workers)
+	}
+}
+
+func (s *ParallelSuite) TestParallelMatchesSerialIn1D() {
+	// Few coordinates, so the update step has to split the points rather than the dimensions
+	points := randomPoints(20000, 1, 2)
+	weights := make([]float64, len(points))
+	for i := range weights {
+		weights[i] = float64(i%7) + 0.1
+	}
+	opts := []kmeans.Option{kmeans.WithK(5), kmeans.WithSeed(4), kmeans.WithMaxIterations(20), kmeans.WithWeights(weights)}
+
+	serial, err := kmeans.Fit(points, append(opts, kmeans.WithWorkers(1))...)
+	s.Require().NoError(err)
+
+	for _, workers := range []int{2, 5, 64} {
+		parallel, err := kmeans.Fit(points, append(opts, kmeans.WithWorkers(workers))...)
+		s.Require().NoError(err)
+
+		s.Equal(serial.Centroids, parallel.Centroids, "workers: %d", workers)
+		s.Equal(serial.Assignments, parallel.Assignments, "workers: %d", workers)
+	}
+}
+
+func (s *ParallelSuite) TestInvalidNumberOfWorkersReturnsError() {
+	_, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithWorkers(0))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfWorkers)
+}
@@ -0,0 +1,82 @@
+package kmeans
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+)
+
+// restart performs cfg.restarts independent runs and returns the one with the lowest SSE.
+//
+// Every restart gets a seed drawn up front from the source of the run, so the set of
+// restarts and the chosen solution do not depend on whether they run concurrently.
+// If a restart fails, e.g. because the context is done or a cluster became empty with
+// FailOnEmptyCluster, the best restart before it is returned together with its error;
+// without any earlier restart the partial result of the failed one is returned instead.
+// Concurrent restarts after the failed one are discarded, as they would not have run one after another.
+func restart(ctx context.Context, points []Point, cfg *config) (*Result, error) {
+	configs := make([]config, cfg.restarts)
+	for i := range configs {
+		configs[i] = *cfg
+		// #nosec G404 -- Deterministic source derived from the master seed
+		configs[i].rng = rand.New(rand.NewSource(cfg.rng.Int63()))
+	}
+
+	results := make([]*Result, cfg.restarts)
+	errs := make([]error, cfg.restarts)
+	runOne := func(i int) {
+		results[i], errs[i] = run(ctx, points, &configs[i])
+	}
+
+	if cfg.parallelRuns {
+		var wg sync.WaitGroup
+		for i := range configs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				runOne(i)
+			}()
+		}
+		wg.Wait()
+	} else {
+		for i := range configs {
+			runOne(i)
+			if errs[i] != nil {
+				break
+			}
+		}
+	}
+
+	return best(results, errs)
+}
+
+// best picks the completed result with the lowest SSE and records the SSE of every restart
+// before the first failed one. It returns that error, if any, along with the best completed
+// (or partial) result.
+func best(results []*Result, errs []error) (*Result, error) {
+	var (
+		chosen   *Result
+		firstErr error
+		partial  *Result
+	)
+
+	sse := make([]float64, 0, len(results))
+	for i, result := range results {
+		if errs[i] != nil {
+			firstErr, partial = errs[i], result
+			break
+		}
+
+		sse = append(sse, result.SSE)
+		if chosen == nil || result.SSE < chosen.SSE {
+			chosen = result
+		}
+	}
+
+	if chosen == nil {
+		return partial, firstErr
+	}
+
+	chosen.RestartSSE = sse
+	return chosen, firstErr
+}
@@ -0,0 +1,96 @@
+package kmeans_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type RestartsSuite struct {
+	suite.Suite
+}
+
+func TestRestartsSuite(t *testing.T) {
+	suite.Run(t, new(RestartsSuite))
+}
+
+func (s *RestartsSuite) TestKeepsLowestSSE() {
+	centers := []kmeans.Point{{0, 0}, {10, 0}, {0, 10}, {10, 10}, {5, 5}}
+	points := gaussianBlobs(centers, 50, 1, 2)
+
+	result, err := kmeans.Fit(points, kmeans.WithK(5), kmeans.WithSeed(1), kmeans.WithRestarts(8),
+		kmeans.WithSeededInitializer(kmeans.SeededRandomCentroids))
+	s.Require().NoError(err)
+
+	s.Len(result.RestartSSE, 8)
+	s.Equal(slices.Min(result.RestartSSE), result.SSE)
+	s.InDelta(kmeans.CalculateSSE(points, result.Centroids, result.Assignments), result.SSE, 1e-9)
+}
+
+func (s *RestartsSuite) TestParallelMatchesSequential() {
+	points := randomPoints(600, 3, 7)
+	opts := []kmeans.Option{kmeans.WithK(6), kmeans.WithSeed(5), kmeans.WithRestarts(6)}
+
+	sequential, err := kmeans.Fit(points, opts...)
+	s.Require().NoError(err)
+	parallel, err := kmeans.Fit(points, append(opts, kmeans.WithParallelRestarts())...)
+	s.Require().NoError(err)
+
+	s.Equal(sequential.RestartSSE, parallel.RestartSSE)
+	s.Equal(sequential.Centroids, parallel.Centroids)
+	s.Equal(sequential.Assignments, parallel.Assignments)
+}
+
+func (s *RestartsSuite) TestParallelMatchesSequentialOnError() {
+	// Random seeding often picks two copies of the same point, leaving a cluster empty
+	var points []kmeans.Point
+	for _, p := range []kmeans.Point{{0, 0}, {10, 0}, {0, 10}} {
+		for range 5 {
+			points = append(points, p)
+		}
+	}
+
+	partial := 0
+	for seed := range int64(20) {
+		opts := []kmeans.Option{kmeans.WithK(3), kmeans.WithSeed(seed), kmeans.WithRestarts(6),
+			kmeans.WithSeededInitializer(kmeans.SeededRandomCentroids),
+			kmeans.WithEmptyClusterStrategy(kmeans.FailOnEmptyCluster)}
+
+		sequential, seqErr := kmeans.Fit(points, opts...)
+		parallel, parErr := kmeans.Fit(points, append(opts, kmeans.WithParallelRestarts())...)
+
+		s.Equal(seqErr, parErr, "seed %d", seed)
+		s.Require().Equal(sequential == nil, parallel == nil, "seed %d", seed)
+		if sequential != nil {
+			s.Equal(sequential.RestartSSE, parallel.RestartSSE, "seed %d", seed)
+			s.Equal(sequential.Centroids, parallel.Centroids, "seed %d", seed)
+		}
+		if seqErr != nil && sequential != nil && len(sequential.RestartSSE) > 0 {
+			partial++
+		}
+	}
+	s.Positive(partial) // some seeds fail after a few successful restarts
+}
+
+func (s *RestartsSuite) TestSingleRunReportsItsSSE() {
+	result, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithSeed(1))
+	s.Require().NoError(err)
+
+	s.Equal([]float64{result.SSE}, result.RestartSSE)
+}
+
+func (s *RestartsSuite) TestMiniBatchRestarts() {
+	result, err := kmeans.MiniBatchKMeans(randomPoints(600, 2, 3),
+		kmeans.WithK(4), kmeans.WithSeed(2), kmeans.WithRestarts(3), kmeans.WithBatchSize(50))
+	s.Require().NoError(err)
+
+	s.Len(result.RestartSSE, 3)
+	s.Equal(slices.Min(result.RestartSSE), result.SSE)
+}
+
+func (s *RestartsSuite) TestInvalidNumberOfRestartsReturnsError() {
+	_, err := kmeans.Fit(twoBlobs(), kmeans.WithK(2), kmeans.WithRestarts(0))
+	s.ErrorIs(err, kmeans.ErrInvalidNumberOfRestarts)
+}
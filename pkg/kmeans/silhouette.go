@@ -0,0 +1,160 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+)
+
+// SilhouetteResult holds the silhouette values of a clustering.
+type SilhouetteResult struct {
+	Mean     float64   // mean silhouette of all evaluated points, in [-1, 1]
+	Points   []float64 // silhouette of every evaluated point
+	Indices  []int     // dataset index of every value in Points; nil when every point was evaluated
+	Clusters []float64 // mean silhouette of the evaluated points of each cluster; NaN if none was evaluated
+}
+
+// Silhouette calculates the silhouette of every point and their means per cluster and overall.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - assignments: index of the cluster assigned to each point
+//   - metric: distance between points; nil means Euclidean
+//
+// Formula:
+//
+//	a(i) = mean distance from x_i to the other points of its cluster
+//	b(i) = min over the other clusters C of the mean distance from x_i to the points of C
+//	s(i) = (b(i) - a(i)) / max(a(i), b(i)), and s(i) = 0 for a point alone in its cluster
+//
+// The exact computation needs O(n²) distances and is split across GOMAXPROCS goroutines;
+// use SampledSilhouette for large datasets.
+func Silhouette(points []Point, assignments []int, metric Metric) (*SilhouetteResult, error) {
+	k, err := validateAssignments(points, assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]int, len(points))
+	for i := range all {
+		all[i] = i
+	}
+
+	result, err := silhouette(points, assignments, all, k, metric)
+	if err != nil {
+		return nil, err
+	}
+	result.Indices = nil // every point was evaluated, in dataset order
+
+	return result, nil
+}
+
+// SampledSilhouette approximates Silhouette on a uniform random sample of sampleSize points.
+// Both a(i) and b(i) are computed within the sample, so the cost drops to O(sampleSize²).
+// Indices tells which points of the dataset the values in Points belong to.
+// A nil rng uses an unseeded source; a sample size not smaller than n gives the exact result.
+func SampledSilhouette(
+	points []Point, assignments []int, metric Metric, sampleSize int, rng *rand.Rand,
+) (*SilhouetteResult, error) {
+	k, err := validateAssignments(points, assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	if sampleSize < 2 {
+		return nil, ErrInvalidSampleSize
+	}
+
+	if sampleSize >= len(points) {
+		return Silhouette(points, assignments, metric)
+	}
+
+	sample := InitConfig{Rand: rng}.random().Perm(len(points))[:sampleSize]
+
+	return silhouette(points, assignments, sample, k, metric)
+}
+
+// silhouette computes the silhouette of the sample points, measuring distances within the sample only.
+func silhouette(points []Point, assignments, sample []int, k int, metric Metric) (*SilhouetteResult, error) {
+	if metric == nil {
+		metric = Euclidean
+	}
+
+	sizes := make([]int, k)
+	for _, i := range sample {
+		sizes[assignments[i]]++
+	}
+
+	nonEmpty := 0
+	for _, size := range sizes {
+		if size > 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty < 2 {
+		return nil, ErrNotEnoughClusters
+	}
+
+	values := make([]float64, len(sample))
+	parallelRows(len(sample), runtime.GOMAXPROCS(0), func(s int) {
+		values[s] = pointSilhouette(points, assignments, sample, sizes, s, metric)
+	})
+
+	return &SilhouetteResult{
+		Mean:     sum(values) / float64(len(values)),
+		Points:   values,
+		Indices:  sample,
+		Clusters: clusterMeans(values, assignments, sample, k),
+	}, nil
+}
+
+// pointSilhouette computes s(i) for the s-th sample point.
+func pointSilhouette(points []Point, assignments, sample, sizes []int, s int, metric Metric) float64 {
+	i := sample[s]
+	own := assignments[i]
+	if sizes[own] == 1 {
+		return 0 // a point alone in its cluster has no cohesion to compare with
+	}
+
+	// Total distance from x_i to the sample points of every cluster
+	totals := make([]float64, len(sizes))
+	for _, j := range sample {
+		if j != i {
+			totals[assignments[j]] += metric.Distance(points[i], points[j])
+		}
+	}
+
+	a := totals[own] / float64(sizes[own]-1)
+	b := math.Inf(1)
+	for c, total := range totals {
+		if c != own && sizes[c] > 0 {
+			b = min(b, total/float64(sizes[c]))
+		}
+	}
+
+	if a == 0 && b == 0 {
+		return 0 // duplicate points spread over several clusters
+	}
+
+	return (b - a) / max(a, b)
+}
+
+// clusterMeans averages the values of the sample points per cluster; clusters without sample points get NaN.
+func clusterMeans(values []float64, assignments, sample []int, k int) []float64 {
+	sums := make([]float64, k)
+	counts := make([]int, k)
+	for s, i := range sample {
+		sums[assignments[i]] += values[s]
+		counts[assignments[i]]++
+	}
+
+	for c := range sums {
+		if counts[c] == 0 {
+			sums[c] = math.NaN()
+		} else {
+			sums[c] /= float64(counts[c])
+		}
+	}
+
+	return sums
+}
@@ -0,0 +1,137 @@
+package kmeans_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type SilhouetteSuite struct {
+	suite.Suite
+}
+
+func TestSilhouetteSuite(t *testing.T) {
+	suite.Run(t, new(SilhouetteSuite))
+}
+
+func (s *SilhouetteSuite) TestTwoClusters1D() {
+	points := []kmeans.Point{{0}, {1}, {10}, {11}}
+	assignments := []int{0, 0, 1, 1}
+
+	result, err := kmeans.Silhouette(points, assignments, nil)
+	s.Require().NoError(err)
+
+	outer, inner := 9.5/10.5, 8.5/9.5
+	s.InDeltaSlice([]float64{outer, inner, inner, outer}, result.Points, 1e-9)
+	s.InDeltaSlice([]float64{(outer + inner) / 2, (outer + inner) / 2}, result.Clusters, 1e-9)
+	s.InDelta((outer+inner)/2, result.Mean, 1e-9)
+	s.Nil(result.Indices)
+}
+
+func (s *SilhouetteSuite) TestSingletonClusterScoresZero() {
+	points := []kmeans.Point{{0}, {1}, {10}}
+	assignments := []int{0, 0, 1}
+
+	result, err := kmeans.Silhouette(points, assignments, kmeans.Manhattan)
+	s.Require().NoError(err)
+
+	s.InDelta(0.0, result.Points[2], 1e-9)
+	s.InDelta(0.0, result.Clusters[1], 1e-9)
+	s.InDelta(9.0/10.0, result.Points[0], 1e-9)
+}
+
+func (s *SilhouetteSuite) TestMisassignedPointIsNegative() {
+	points := []kmeans.Point{{0}, {1}, {2}, {10}, {11}}
+	assignments := []int{0, 0, 1, 1, 1}
+
+	result, err := kmeans.Silhouette(points, assignments, nil)
+	s.Require().NoError(err)
+
+	s.Negative(result.Points[2])
+}
+
+func (s *SilhouetteSuite) TestSampledApproximatesExact() {
+	centers := []kmeans.Point{{0, 0}, {10, 0}, {0, 10}}
+	points := gaussianBlobs(centers, 400, 1.5, 6)
+	fit, err := kmeans.Fit(points, kmeans.WithK(3), kmeans.WithSeed(1))
+	s.Require().NoError(err)
+
+	exact, err := kmeans.Silhouette(points, fit.Assignments, kmeans.Euclidean)
+	s.Require().NoError(err)
+	sampled, err := kmeans.SampledSilhouette(points, fit.Assignments, kmeans.Euclidean, 300, rand.New(rand.NewSource(2)))
+	s.Require().NoError(err)
+
+	s.Len(sampled.Points, 300)
+	s.Len(sampled.Indices, 300)
+	s.InDelta(exact.Mean, sampled.Mean, 0.05)
+}
+
+func (s *SilhouetteSuite) TestSampledWithFullSampleIsExact() {
+	points := randomPoints(50, 2, 3)
+	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = i % 3
+	}
+
+	exact, err := kmeans.Silhouette(points, assignments, nil)
+	s.Require().NoError(err)
+	sampled, err := kmeans.SampledSilhouette(points, assignments, nil, 100, nil)
+	s.Require().NoError(err)
+
+	s.Equal(exact, sampled)
+}
+
+func (s *SilhouetteSuite) TestEveryPointOfLargeInputIsScored() {
+	// Enough points for the pass to be split across goroutines
+	points := randomPoints(600, 2, 4)
+	assignments := make([]int, len(points))
+	for i, p := range points {
+		if p[0] > 50 {
+			assignments[i] = 1
+		}
+	}
+
+	result, err := kmeans.Silhouette(points, assignments, nil)
+	s.Require().NoError(err)
+
+	for i := range points {
+		var within, between [2]float64
+		for j := range points {
+			within[assignments[j]] += kmeans.Euclidean.Distance(points[i], points[j])
+			between[assignments[j]]++
+		}
+		own := assignments[i]
+		a := within[own] / (between[own] - 1)
+		b := within[1-own] / between[1-own]
+		s.InDelta((b-a)/max(a, b), result.Points[i], 1e-9, "point %d", i)
+	}
+}
+
+func (s *SilhouetteSuite) TestClusterWithoutSampledPointsIsNaN() {
+	points := []kmeans.Point{{0}, {1}, {10}, {11}}
+	assignments := []int{0, 0, 2, 2}
+
+	result, err := kmeans.Silhouette(points, assignments, nil)
+	s.Require().NoError(err)
+
+	s.True(math.IsNaN(result.Clusters[1]))
+}
+
+func (s *SilhouetteSuite) TestInvalidInputReturnsError() {
+	points := []kmeans.Point{{0}, {1}, {10}}
+
+	_, err := kmeans.Silhouette(points, []int{0, 0}, nil)
+	s.ErrorIs(err, kmeans.ErrAssignmentsMismatch)
+
+	_, err = kmeans.Silhouette(points, []int{0, -1, 1}, nil)
+	s.ErrorIs(err, kmeans.ErrInvalidAssignment)
+
+	_, err = kmeans.Silhouette(points, []int{0, 0, 0}, nil)
+	s.ErrorIs(err, kmeans.ErrNotEnoughClusters)
+
+	_, err = kmeans.SampledSilhouette(points, []int{0, 0, 1}, nil, 1, nil)
+	s.ErrorIs(err, kmeans.ErrInvalidSampleSize)
+}
@@ -0,0 +1,120 @@
+package kmeans
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// unitNormTolerance is how far the length of a point may be from 1 with WithPrenormalized.
+const unitNormTolerance = 1e-6
+
+// SphericalKMeans performs spherical k-means clustering (Dhillon and Modha), the k-means of
+// directions: points are scaled to unit length, assigned to the centroid with the largest dot
+// product, i.e. cosine similarity, and every centroid is the mean of its points projected back
+// onto the unit sphere. It suits text embeddings and other data where only the angle matters.
+// It is equivalent to SphericalKMeansContext with a background context.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points; none may be the zero vector
+//   - opts: run configuration as for Fit; WithK is required, WithMetric is ignored
+//
+// Returns:
+//   - result: unit-length centroids, assignments and statistics of the run, as reported by Fit
+//   - err: one of the Err* validation errors if the input or options are invalid
+func SphericalKMeans(points []Point, opts ...Option) (*Result, error) {
+	return SphericalKMeansContext(context.Background(), points, opts...)
+}
+
+// SphericalKMeansContext performs spherical k-means clustering like SphericalKMeans, but stops
+// as soon as ctx is done, returning a partial Result as FitContext does.
+//
+// The points are normalized into a copy; with WithPrenormalized they are only checked to have
+// unit length. The starting centroids are chosen with SeededCosineSmartCentroids unless another
+// initializer is set. Result.SSE is the squared Euclidean error of the unit vectors, Σ 2(1 - cos),
+// and the stop criteria, restarts and empty cluster strategies are those of Fit. Lloyd, Elkan and
+// Hamerly are supported; mini-batches return ErrMetricNotSupported.
+//
+// Arguments:
+//   - ctx: controls cancellation and deadline of the run
+//   - points: dataset of n-dimensional points; none may be the zero vector
+//   - opts: run configuration as for Fit; WithK is required, WithMetric is ignored
+//
+// Returns:
+//   - result: unit-length centroids, assignments and statistics of the run; nil only for invalid input
+//   - err: one of the Err* validation errors, or ctx.Err() if the run was interrupted
+func SphericalKMeansContext(ctx context.Context, points []Point, opts ...Option) (*Result, error) {
+	all := append([]Option{WithSeededInitializer(SeededCosineSmartCentroids)}, opts...)
+	cfg := newConfig(append(all, WithMetric(unitDotMetric{})))
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if cfg.algorithm == MiniBatch {
+		return nil, fmt.Errorf("%w: spherical k-means does not support %s", ErrMetricNotSupported, cfg.algorithm)
+	}
+
+	for i, p := range points {
+		norm := math.Sqrt(dot(p, p))
+		if norm == 0 {
+			return nil, fmt.Errorf("%w: point %d", ErrZeroVector, i)
+		}
+		if cfg.prenormalized && math.Abs(norm-1) > unitNormTolerance {
+			return nil, fmt.Errorf("%w: point %d has length %g", ErrNotUnitLength, i, norm)
+		}
+	}
+
+	if !cfg.prenormalized {
+		points = NormalizeUnitLength(points)
+	}
+
+	// Any initializer gives unit-length starting centroids, as the dot product assignment needs
+	initializer := cfg.initializer
+	cfg.initializer = func(points []Point, k int, init InitConfig) []Point {
+		return NormalizeUnitLength(initializer(points, k, init))
+	}
+	cfg.spherical = true
+
+	return fit(ctx, points, &cfg)
+}
+
+// CosineSmartCentroids initializes centroids with spherical k-means++: the points are scaled to
+// unit length and every next centroid is picked with probability proportional to its cosine
+// distance 1 - cos to the nearest centroid chosen so far. It returns the chosen points normalized.
+func CosineSmartCentroids(points []Point, k int) []Point {
+	return SeededCosineSmartCentroids(points, k, InitConfig{})
+}
+
+// SeededCosineSmartCentroids works like CosineSmartCentroids but draws every random choice from cfg.Rand,
+// so a fixed seed always selects the same points. cfg.Metric is ignored; with cfg.Weights every pick
+// is proportional to weight × distance, as in SeededSmartCentroids.
+func SeededCosineSmartCentroids(points []Point, k int, cfg InitConfig) []Point {
+	// On the unit sphere the squared Euclidean distance is 2(1 - cos), proportional to the cosine distance
+	unit := InitConfig{Rand: cfg.Rand, Metric: unitDotMetric{}, Workers: cfg.Workers, Weights: cfg.Weights}
+
+	return SeededSmartCentroids(NormalizeUnitLength(points), k, unit)
+}
+
+// updateSphericalCentroids moves every centroid to the (weighted) mean of its assigned points scaled to
+// unit length. A centroid without any points, or whose points cancel out, keeps its previous position.
+// It returns the largest distance any centroid moved.
+func updateSphericalCentroids(points, centroids []Point, assignments []int, weights []float64, workers int) float64 {
+	sums, totals := clusterSums(points, assignments, weights, len(centroids), workers)
+
+	maxShift := 0.0
+	for j, sum := range sums {
+		norm := math.Sqrt(dot(sum, sum))
+		if totals[j] == 0 || norm == 0 {
+			continue
+		}
+
+		// The mean and the sum have the same direction
+		for d := range sum {
+			sum[d] /= norm
+		}
+		maxShift = math.Max(maxShift, distance(centroids[j], sum))
+		centroids[j] = sum
+	}
+
+	return maxShift
+}
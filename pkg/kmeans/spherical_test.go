@@ -0,0 +1,139 @@
+package kmeans_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type SphericalSuite struct {
+	suite.Suite
+}
+
+func TestSphericalSuite(t *testing.T) {
+	suite.Run(t, new(SphericalSuite))
+}
+
+// rays returns points scattered around the three coordinate axes at lengths between 1 and 50,
+// together with the axis of every point. Only their direction tells the groups apart.
+func rays(perRay int, seed int64) ([]kmeans.Point, []int) {
+	rng := rand.New(rand.NewSource(seed))
+	var points []kmeans.Point
+	var labels []int
+	for axis := range 3 {
+		for range perRay {
+			length := 1 + 49*rng.Float64()
+			p := make(kmeans.Point, 3)
+			for d := range p {
+				p[d] = length * 0.15 * rng.NormFloat64()
+			}
+			p[axis] += length
+			points = append(points, p)
+			labels = append(labels, axis)
+		}
+	}
+	return points, labels
+}
+
+// norm returns the Euclidean length of the point.
+func norm(p kmeans.Point) float64 {
+	return kmeans.Euclidean.Distance(p, make(kmeans.Point, len(p)))
+}
+
+func (s *SphericalSuite) TestGroupsByDirection() {
+	points, labels := rays(100, 1)
+
+	spherical, err := kmeans.SphericalKMeans(points, kmeans.WithK(3), kmeans.WithSeed(2))
+	s.Require().NoError(err)
+	euclidean, err := kmeans.Fit(points, kmeans.WithK(3), kmeans.WithSeed(2))
+	s.Require().NoError(err)
+
+	sphericalARI, err := kmeans.AdjustedRandIndex(labels, spherical.Assignments)
+	s.Require().NoError(err)
+	euclideanARI, err := kmeans.AdjustedRandIndex(labels, euclidean.Assignments)
+	s.Require().NoError(err)
+
+	s.InDelta(1.0, sphericalARI, 1e-12)
+	s.Less(euclideanARI, 0.9)
+	for _, c := range spherical.Centroids {
+		s.InDelta(1.0, norm(c), 1e-12)
+	}
+}
+
+func (s *SphericalSuite) TestSSEIsCosineError() {
+	points, _ := rays(50, 3)
+
+	result, err := kmeans.SphericalKMeans(points, kmeans.WithK(3), kmeans.WithSeed(4))
+	s.Require().NoError(err)
+
+	expected := 0.0
+	for i, p := range points {
+		expected += 2 * kmeans.Cosine.Distance(p, result.Centroids[result.Assignments[i]])
+	}
+	s.InDelta(expected, result.SSE, 1e-9)
+}
+
+func (s *SphericalSuite) TestAcceleratedAlgorithmsMatchLloyd() {
+	points, _ := rays(200, 5)
+
+	lloyd, err := kmeans.SphericalKMeans(points, kmeans.WithK(6), kmeans.WithSeed(6))
+	s.Require().NoError(err)
+
+	for _, algorithm := range []kmeans.Algorithm{kmeans.Elkan, kmeans.Hamerly} {
+		result, err := kmeans.SphericalKMeans(points, kmeans.WithK(6), kmeans.WithSeed(6), kmeans.WithAlgorithm(algorithm))
+		s.Require().NoError(err, algorithm.String())
+
+		s.Equal(lloyd.Assignments, result.Assignments, algorithm.String())
+		s.Equal(lloyd.Iterations, result.Iterations)
+	}
+}
+
+func (s *SphericalSuite) TestPrenormalizedInput() {
+	points := kmeans.NormalizeUnitLength([]kmeans.Point{{1, 0.1}, {1, 0.2}, {0.1, 1}, {0.2, 1}})
+
+	result, err := kmeans.SphericalKMeans(points, kmeans.WithK(2), kmeans.WithSeed(7), kmeans.WithPrenormalized())
+	s.Require().NoError(err)
+	s.Equal(result.Assignments[0], result.Assignments[1])
+	s.NotEqual(result.Assignments[0], result.Assignments[2])
+
+	_, err = kmeans.SphericalKMeans([]kmeans.Point{{1, 0}, {0, 2}}, kmeans.WithK(2), kmeans.WithPrenormalized())
+	s.ErrorIs(err, kmeans.ErrNotUnitLength)
+}
+
+func (s *SphericalSuite) TestCosineSmartCentroids() {
+	points, _ := rays(50, 8)
+
+	first := kmeans.SeededCosineSmartCentroids(points, 3, kmeans.InitConfig{Rand: rand.New(rand.NewSource(9))})
+	second := kmeans.SeededCosineSmartCentroids(points, 3, kmeans.InitConfig{Rand: rand.New(rand.NewSource(9))})
+
+	s.Equal(first, second)
+	s.Len(first, 3)
+	for _, c := range first {
+		s.InDelta(1.0, norm(c), 1e-12)
+	}
+}
+
+func (s *SphericalSuite) TestNormalizeUnitLength() {
+	points := []kmeans.Point{{3, 4}, {0, 0}, {0, -2}}
+
+	normalized := kmeans.NormalizeUnitLength(points)
+
+	s.InDeltaSlice(kmeans.Point{0.6, 0.8}, normalized[0], 1e-12)
+	s.Equal(kmeans.Point{0, 0}, normalized[1])
+	s.Equal(kmeans.Point{0, -1}, normalized[2])
+	s.Equal(kmeans.Point{3, 4}, points[0]) // the input is left untouched
+	s.InDelta(1.0, norm(normalized[0]), 1e-12)
+}
+
+func (s *SphericalSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.SphericalKMeans([]kmeans.Point{{1, 0}, {0, 0}}, kmeans.WithK(2))
+	s.ErrorIs(err, kmeans.ErrZeroVector)
+
+	_, err = kmeans.SphericalKMeans(twoBlobs(), kmeans.WithK(2), kmeans.WithAlgorithm(kmeans.MiniBatch))
+	s.ErrorIs(err, kmeans.ErrMetricNotSupported)
+
+	_, err = kmeans.SphericalKMeans(twoBlobs())
+	s.ErrorIs(err, kmeans.ErrNegativeNumberOfClusters)
+}
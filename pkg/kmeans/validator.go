@@ -2,16 +2,57 @@ package kmeans
 
 import (
 	"errors"
+	"fmt"
 	"math"
 )
 
 var (
-	ErrNoPoints                  = errors.New("no points provided")
-	ErrNegativeNumberOfClusters  = errors.New("number of clusters must be positive")
-	ErrNotEnoughPoints           = errors.New("not enough points for clusters")
-	ErrInvalidNumberOfDimensions = errors.New("points must have at least one dimension")
-	ErrInconsistentDimensions    = errors.New("points must all have the same number of dimensions")
-	ErrInvalidNumericValue       = errors.New("points contain invalid numeric values (NaN or Inf)")
+	ErrNoPoints                    = errors.New("no points provided")
+	ErrNegativeNumberOfClusters    = errors.New("number of clusters must be positive")
+	ErrNotEnoughPoints             = errors.New("not enough points for clusters")
+	ErrInvalidNumberOfDimensions   = errors.New("points must have at least one dimension")
+	ErrInconsistentDimensions      = errors.New("points must all have the same number of dimensions")
+	ErrInvalidNumericValue         = errors.New("points contain invalid numeric values (NaN or Inf)")
+	ErrInvalidNumberOfIterations   = errors.New("number of iterations must be positive")
+	ErrInvalidTolerance            = errors.New("tolerance must be a non-negative number")
+	ErrInvalidNumberOfWorkers      = errors.New("number of workers must be positive")
+	ErrInvalidMetric               = errors.New("invalid metric parameters")
+	ErrMetricNotMeanCompatible     = errors.New("the arithmetic mean is not the optimal centroid for this metric")
+	ErrUnknownAlgorithm            = errors.New("unknown algorithm")
+	ErrInvalidBatchSize            = errors.New("batch size must be positive")
+	ErrInvalidNoImprovement        = errors.New("number of batches without improvement must not be negative")
+	ErrMetricNotSupported          = errors.New("metric is not supported by the algorithm")
+	ErrInvalidNumberOfRestarts     = errors.New("number of restarts must be positive")
+	ErrUnknownEmptyClusterStrategy = errors.New("unknown empty cluster strategy")
+	ErrEmptyCluster                = errors.New("a cluster received no points")
+	ErrAssignmentsMismatch         = errors.New("assignments must have exactly one entry per point")
+	ErrInvalidAssignment           = errors.New("assignments must be non-negative cluster indices")
+	ErrNotEnoughClusters           = errors.New("at least two non-empty clusters are required")
+	ErrInvalidSampleSize           = errors.New("sample size must be at least 2")
+	ErrDegenerateClusters          = errors.New("the index is undefined for this partition")
+	ErrLabelsMismatch              = errors.New("labels and assignments must have the same length")
+	ErrInvalidLabel                = errors.New("labels must be non-negative class indices")
+	ErrInvalidKRange               = errors.New("invalid range of k")
+	ErrUnknownSelectionMethod      = errors.New("unknown k selection method")
+	ErrInvalidNumberOfReferences   = errors.New("number of reference datasets must be positive")
+	ErrInvalidSignificance         = errors.New("significance level must be between 0 and 1")
+	ErrUnknownSplitCriterion       = errors.New("unknown split criterion")
+	ErrNotEnoughDistinctPoints     = errors.New("not enough distinct points for clusters")
+	ErrUnknownMedoidsMethod        = errors.New("unknown k-medoids method")
+	ErrInvalidDissimilarityMatrix  = errors.New("dissimilarities must be a square matrix of non-negative finite values")
+	ErrInvalidNumberOfSamples      = errors.New("number of samples must be positive")
+	ErrSampleTooSmall              = errors.New("sample size must be at least the number of clusters")
+	ErrInvalidNumberOfSearches     = errors.New("number of local searches must be positive")
+	ErrInvalidNumberOfNeighbors    = errors.New("maximum number of neighbors must not be negative")
+	ErrInvalidFuzzifier            = errors.New("fuzzifier must be a finite number greater than 1")
+	ErrZeroVector                  = errors.New("points must not be zero vectors")
+	ErrNotUnitLength               = errors.New("points must have unit length")
+	ErrWeightsMismatch             = errors.New("weights must have exactly one entry per point")
+	ErrInvalidWeight               = errors.New("weights must be non-negative finite numbers")
+	ErrZeroTotalWeight             = errors.New("at least one weight must be positive")
+	ErrWeightsNotSupported         = errors.New("sample weights are not supported by the algorithm")
+	ErrInvalidSizeBounds           = errors.New("cluster size bounds cannot be satisfied")
+	ErrAlgorithmNotSupported       = errors.New("algorithm is not supported in this mode")
 )
 
 func ValidatePoints(points []Point, k int) error {
@@ -45,3 +86,56 @@ func ValidatePoints(points []Point, k int) error {
 
 	return nil
 }
+
+// ValidateWeightedPoints checks the points like ValidatePoints, and their sample weights:
+// one per point, none negative, NaN or infinite, and at least one positive.
+// A nil weights slice stands for unit weights and is always valid.
+func ValidateWeightedPoints(points []Point, weights []float64, k int) error {
+	if err := ValidatePoints(points, k); err != nil {
+		return err
+	}
+
+	if weights == nil {
+		return nil
+	}
+
+	if len(weights) != len(points) {
+		return ErrWeightsMismatch
+	}
+
+	total := 0.0
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return fmt.Errorf("%w: weight %d is %g", ErrInvalidWeight, i, w)
+		}
+		total += w
+	}
+
+	if total == 0 {
+		return ErrZeroTotalWeight
+	}
+
+	return nil
+}
+
+// validateAssignments checks that there is one non-negative cluster index per point.
+// It returns the number of clusters, i.e. the largest index plus one.
+func validateAssignments(points []Point, assignments []int) (int, error) {
+	if len(points) == 0 {
+		return 0, ErrNoPoints
+	}
+
+	if len(assignments) != len(points) {
+		return 0, ErrAssignmentsMismatch
+	}
+
+	k := 0
+	for _, a := range assignments {
+		if a < 0 {
+			return 0, ErrInvalidAssignment
+		}
+		k = max(k, a+1)
+	}
+
+	return k, nil
+}
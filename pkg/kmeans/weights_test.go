@@ -0,0 +1,186 @@
+package kmeans_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type WeightsSuite struct {
+	suite.Suite
+}
+
+func TestWeightsSuite(t *testing.T) {
+	suite.Run(t, new(WeightsSuite))
+}
+
+// aggregatedRows returns a few distinct points with the number of observations each stands for.
+func aggregatedRows() ([]kmeans.Point, []float64) {
+	points := []kmeans.Point{{0, 0}, {1, 0}, {0, 3}, {10, 10}, {12, 10}, {10, 13}}
+	weights := []float64{5, 1, 2, 1, 3, 4}
+	return points, weights
+}
+
+// expand repeats every point as many times as its integer weight.
+func expand(points []kmeans.Point, weights []float64) []kmeans.Point {
+	var expanded []kmeans.Point
+	for i, p := range points {
+		for range int(weights[i]) {
+			expanded = append(expanded, p)
+		}
+	}
+	return expanded
+}
+
+// fixedStart starts every run from the same two centroids.
+func fixedStart(_ []kmeans.Point, _ int) []kmeans.Point {
+	return []kmeans.Point{{0, 0}, {10, 10}}
+}
+
+func (s *WeightsSuite) TestWeightsActLikeRepeatedPoints() {
+	points, weights := aggregatedRows()
+
+	weighted, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithInitializer(fixedStart), kmeans.WithWeights(weights))
+	s.Require().NoError(err)
+	repeated, err := kmeans.Fit(expand(points, weights), kmeans.WithK(2), kmeans.WithInitializer(fixedStart))
+	s.Require().NoError(err)
+
+	for j := range weighted.Centroids {
+		s.InDeltaSlice(repeated.Centroids[j], weighted.Centroids[j], 1e-12)
+	}
+	s.InDelta(repeated.SSE, weighted.SSE, 1e-9)
+	s.Equal([]int{3, 3}, weighted.ClusterSizes) // sizes still count the points
+	s.InDeltaSlice(kmeans.Point{1.0 / 8, 6.0 / 8}, weighted.Centroids[0], 1e-12)
+}
+
+func (s *WeightsSuite) TestZeroWeightIsIgnored() {
+	points := []kmeans.Point{{0, 0}, {2, 0}, {1000, 0}, {50, 50}, {52, 50}}
+	weights := []float64{1, 1, 0, 1, 1}
+
+	result, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithSeed(1), kmeans.WithWeights(weights))
+	s.Require().NoError(err)
+
+	centroids := result.Centroids
+	if centroids[0][0] > centroids[1][0] {
+		centroids[0], centroids[1] = centroids[1], centroids[0]
+	}
+	s.Equal(kmeans.Point{1, 0}, centroids[0])
+	s.Equal(kmeans.Point{51, 50}, centroids[1])
+}
+
+func (s *WeightsSuite) TestWeightedSSE() {
+	points, weights := aggregatedRows()
+	centroids := []kmeans.Point{{0, 0}, {10, 10}}
+	assignments := []int{0, 0, 0, 1, 1, 1}
+
+	s.InDelta(1*1+2*9+3*4+4*9.0, kmeans.CalculateWeightedSSE(points, centroids, assignments, weights), 1e-12)
+	s.InDelta(kmeans.CalculateSSE(points, centroids, assignments),
+		kmeans.CalculateWeightedSSE(points, centroids, assignments, nil), 1e-12)
+
+	result, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithSeed(2), kmeans.WithWeights(weights))
+	s.Require().NoError(err)
+	s.InDelta(kmeans.CalculateWeightedSSE(points, result.Centroids, result.Assignments, weights), result.SSE, 1e-9)
+}
+
+func (s *WeightsSuite) TestSeedingSkipsZeroWeights() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {5, 5}}, 50, 1, 3)
+	weights := make([]float64, len(points))
+	weights[7], weights[60], weights[99] = 1, 2, 3
+
+	initializers := map[string]kmeans.SeededInitializer{
+		"k-means++": kmeans.SeededSmartCentroids,
+		"k-means||": kmeans.SeededParallelPlusPlusCentroids,
+	}
+	for name, initializer := range initializers {
+		for seed := range int64(20) {
+			init := kmeans.InitConfig{Rand: rand.New(rand.NewSource(seed)), Weights: weights}
+			centroids := initializer(points, 3, init)
+
+			s.ElementsMatch([]kmeans.Point{points[7], points[60], points[99]}, centroids, name)
+		}
+	}
+}
+
+func (s *WeightsSuite) TestSphericalSeedingSkipsZeroWeights() {
+	points, _ := rays(50, 10)
+	weights := make([]float64, len(points))
+	weights[7], weights[60], weights[120] = 1, 2, 3 // one point on every axis
+
+	for seed := range int64(10) {
+		result, err := kmeans.SphericalKMeans(points, kmeans.WithK(3), kmeans.WithSeed(seed), kmeans.WithWeights(weights))
+		s.Require().NoError(err)
+
+		// Every centroid is seeded on a weighted point and stays there, the only weight of its cluster
+		expected := kmeans.NormalizeUnitLength([]kmeans.Point{points[7], points[60], points[120]})
+		for _, want := range expected {
+			found := false
+			for _, c := range result.Centroids {
+				found = found || kmeans.Euclidean.Distance(want, c) < 1e-12
+			}
+			s.True(found, "seed %d: %v not among %v", seed, want, result.Centroids)
+		}
+	}
+}
+
+func (s *WeightsSuite) TestMiniBatchSamplesByWeight() {
+	points := gaussianBlobs([]kmeans.Point{{0, 0}, {20, 0}}, 200, 1, 4)
+	points = append(points, kmeans.Point{1e6, 1e6})
+	weights := make([]float64, len(points))
+	for i := range weights {
+		weights[i] = 1
+	}
+	weights[len(points)-1] = 0
+
+	result, err := kmeans.MiniBatchKMeans(points, kmeans.WithK(2), kmeans.WithSeed(5), kmeans.WithWeights(weights))
+	s.Require().NoError(err)
+
+	for _, c := range result.Centroids {
+		s.Less(math.Abs(c[1]), 1.0) // the outlier never enters a batch
+	}
+}
+
+func (s *WeightsSuite) TestValidateWeightedPoints() {
+	points := twoBlobs()
+
+	s.NoError(kmeans.ValidateWeightedPoints(points, nil, 2))
+	s.NoError(kmeans.ValidateWeightedPoints(points, []float64{0, 1, 2, 3, 0, 0.5}, 2))
+	s.ErrorIs(kmeans.ValidateWeightedPoints(points, []float64{1, 1}, 2), kmeans.ErrWeightsMismatch)
+	s.ErrorIs(kmeans.ValidateWeightedPoints(points, []float64{1, 1, -1, 1, 1, 1}, 2), kmeans.ErrInvalidWeight)
+	s.ErrorIs(kmeans.ValidateWeightedPoints(points, []float64{1, 1, math.NaN(), 1, 1, 1}, 2), kmeans.ErrInvalidWeight)
+	s.ErrorIs(kmeans.ValidateWeightedPoints(points, []float64{1, 1, math.Inf(1), 1, 1, 1}, 2), kmeans.ErrInvalidWeight)
+	s.ErrorIs(kmeans.ValidateWeightedPoints(points, make([]float64, 6), 2), kmeans.ErrZeroTotalWeight)
+	s.ErrorIs(kmeans.ValidateWeightedPoints(points, nil, 7), kmeans.ErrNotEnoughPoints)
+
+	_, err := kmeans.Fit(points, kmeans.WithK(2), kmeans.WithWeights([]float64{-1, 1, 1, 1, 1, 1}))
+	s.ErrorIs(err, kmeans.ErrInvalidWeight)
+}
+
+func (s *WeightsSuite) TestUnsupportedAlgorithmsReturnError() {
+	points := fourBlobs()
+	weights := make([]float64, len(points))
+	for i := range weights {
+		weights[i] = 2
+	}
+	withWeights := kmeans.WithWeights(weights)
+
+	_, err := kmeans.KMedians(points, kmeans.WithK(4), withWeights)
+	s.ErrorIs(err, kmeans.ErrWeightsNotSupported)
+
+	_, err = kmeans.KMedoids(points, 4, kmeans.PAM, withWeights)
+	s.ErrorIs(err, kmeans.ErrWeightsNotSupported)
+
+	_, err = kmeans.BisectingKMeans(points, 4, kmeans.LargestSSE, withWeights)
+	s.ErrorIs(err, kmeans.ErrWeightsNotSupported)
+
+	_, err = kmeans.ChooseK(points, 2, 5, kmeans.GapStatistic, withWeights)
+	s.ErrorIs(err, kmeans.ErrWeightsNotSupported)
+
+	elbow, err := kmeans.ChooseK(points, 2, 6, kmeans.Elbow, withWeights, kmeans.WithSeed(6))
+	s.Require().NoError(err)
+	for i, result := range elbow.Results {
+		s.InDelta(kmeans.CalculateWeightedSSE(points, result.Centroids, result.Assignments, weights), elbow.SSE[i], 1e-9)
+	}
+}
@@ -0,0 +1,208 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// XMeansResult describes the outcome of XMeans.
+type XMeansResult struct {
+	K           int       // final number of clusters
+	Centroids   []Point   // final positions of the cluster centroids
+	Assignments []int     // index of the centroid assigned to each point
+	BIC         []float64 // BIC of the model after the first run and after every round that split clusters
+	Result      *Result   // final Lloyd run over all points, with its SSE, sizes and stop reason
+}
+
+// XMeans clusters the points with X-means (Pelleg and Moore), which estimates the number of clusters.
+//
+// It runs k-means with kMin clusters, then repeatedly tries to split every cluster in two
+// with a local 2-means on its points. A split is kept when it improves the BIC of the cluster's
+// points; if more splits improve it than kMax allows, the largest improvements win.
+// The kept centroids and the children seed a new run over all points, until no split helps
+// or kMax is reached. A cluster covering a symmetric arrangement of groups may not improve
+// when halved, so a larger kMin helps when such layouts are expected.
+//
+// Arguments:
+//   - points: dataset of n-dimensional points
+//   - kMin, kMax: range of the number of clusters, 1 <= kMin <= kMax <= n
+//   - opts: options of every Fit run; WithK and the initializer of the global runs are ignored
+//
+// Returns:
+//   - result: final k, centroids, assignments and the BIC trace
+//   - err: one of the Err* validation errors, or the first error of a Fit run
+func XMeans(points []Point, kMin, kMax int, opts ...Option) (*XMeansResult, error) {
+	if kMin < 1 || kMax < kMin {
+		return nil, fmt.Errorf("%w: [%d, %d]", ErrInvalidKRange, kMin, kMax)
+	}
+
+	cfg := newConfig(append(slices.Clone(opts), WithK(kMax)))
+	if err := cfg.validate(points); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.rejectWeights("X-means"); err != nil {
+		return nil, err
+	}
+
+	x := &splitter{opts: opts, rng: cfg.rng}
+	result, err := x.fit(points, kMin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := []float64{CalculateBIC(points, result.Centroids, result.Assignments)}
+	for len(result.Centroids) < kMax {
+		centroids, err := x.splitByBIC(points, result, kMax)
+		if err != nil {
+			return nil, err
+		}
+		if len(centroids) == len(result.Centroids) {
+			break // no split improves the BIC
+		}
+
+		if result, err = x.fit(points, len(centroids), centroids); err != nil {
+			return nil, err
+		}
+		trace = append(trace, CalculateBIC(points, result.Centroids, result.Assignments))
+	}
+
+	return &XMeansResult{
+		K:           len(result.Centroids),
+		Centroids:   result.Centroids,
+		Assignments: result.Assignments,
+		BIC:         trace,
+		Result:      result,
+	}, nil
+}
+
+// CalculateBIC calculates the Bayesian Information Criterion of the clustering, modelling
+// every cluster as a spherical Gaussian with a shared variance. Higher is better.
+//
+// Arguments:
+//   - points: dataset of n points with M dimensions
+//   - centroids: the K cluster centroids
+//   - assignments: index of the centroid assigned to each point
+//
+// Returns:
+//   - BIC: float64; -Inf if n <= K, since the variance is then undefined, and +Inf if the SSE is 0
+//
+// Formula:
+//
+//	σ² = SSE / (M · (n - K))
+//	l  = Σ_j n_j log(n_j / n) - n·M/2 · log(2π σ²) - M · (n - K) / 2
+//	p  = (K - 1) + M·K + 1
+//	BIC = l - p/2 · log(n)
+func CalculateBIC(points, centroids []Point, assignments []int) float64 {
+	n, m, k := float64(len(points)), float64(len(points[0])), float64(len(centroids))
+	if n <= k {
+		return math.Inf(-1)
+	}
+
+	sse := CalculateSSE(points, centroids, assignments)
+	if sse == 0 {
+		return math.Inf(1)
+	}
+
+	variance := sse / (m * (n - k))
+	logLikelihood := -n*m/2*math.Log(2*math.Pi*variance) - m*(n-k)/2
+	for _, size := range clusterSizes(assignments, len(centroids)) {
+		if size > 0 {
+			logLikelihood += float64(size) * math.Log(float64(size)/n)
+		}
+	}
+
+	parameters := (k - 1) + m*k + 1 // mixing weights, centroids and the variance
+
+	return logLikelihood - parameters/2*math.Log(n)
+}
+
+// splitter holds the options shared by the runs of XMeans and GMeans
+// and the source their seeds are drawn from.
+type splitter struct {
+	opts []Option
+	rng  *rand.Rand
+}
+
+// splitCandidate is a cluster that should be replaced by its two children.
+type splitCandidate struct {
+	parent   int
+	children []Point
+	gain     float64 // strength of the case for splitting; the largest gains are kept first
+}
+
+// fit runs k-means with k clusters and a seed drawn from the source of the splitter,
+// starting from the given centroids if they are not nil.
+func (x *splitter) fit(points []Point, k int, centroids []Point) (*Result, error) {
+	opts := append(slices.Clone(x.opts), WithK(k), WithSeed(x.rng.Int63()))
+	if centroids != nil {
+		opts = append(opts, WithSeededInitializer(func([]Point, int, InitConfig) []Point {
+			return centroids
+		}))
+	}
+
+	return Fit(points, opts...)
+}
+
+// splitByBIC tries to split every cluster of the result in two and returns the centroids of the next run,
+// keeping the splits that improve the BIC of the cluster's points.
+func (x *splitter) splitByBIC(points []Point, result *Result, kMax int) ([]Point, error) {
+	var candidates []splitCandidate
+	for j, cluster := range clusterMembers(points, result.Assignments, len(result.Centroids)) {
+		if len(cluster) < 2 {
+			continue
+		}
+
+		children, err := x.fit(cluster, 2, nil)
+		if err != nil {
+			return nil, fmt.Errorf("splitting cluster %d: %w", j, err)
+		}
+
+		parent := CalculateBIC(cluster, result.Centroids[j:j+1], make([]int, len(cluster)))
+		child := CalculateBIC(cluster, children.Centroids, children.Assignments)
+		if child > parent {
+			candidates = append(candidates, splitCandidate{parent: j, children: children.Centroids, gain: child - parent})
+		}
+	}
+
+	return applySplits(result.Centroids, candidates, kMax), nil
+}
+
+// clusterMembers groups the points by their assigned cluster.
+func clusterMembers(points []Point, assignments []int, k int) [][]Point {
+	members := make([][]Point, k)
+	for i, a := range assignments {
+		members[a] = append(members[a], points[i])
+	}
+
+	return members
+}
+
+// applySplits returns the parents that are not split followed by the children of the kept splits.
+// If there are more candidates than kMax allows, the largest gains are kept.
+func applySplits(parents []Point, candidates []splitCandidate, kMax int) []Point {
+	// Keep the largest improvements if there are more splits than kMax allows
+	slices.SortStableFunc(candidates, func(a, b splitCandidate) int {
+		return cmp.Compare(b.gain, a.gain)
+	})
+	candidates = candidates[:min(len(candidates), kMax-len(parents))]
+
+	split := make([]bool, len(parents))
+	var added []Point
+	for _, candidate := range candidates {
+		split[candidate.parent] = true
+		added = append(added, candidate.children...)
+	}
+
+	var centroids []Point
+	for j, centroid := range parents {
+		if !split[j] {
+			centroids = append(centroids, centroid)
+		}
+	}
+
+	return append(centroids, added...)
+}
@@ -0,0 +1,99 @@
+package kmeans_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lukeweb/k-means-algorithm-go/pkg/kmeans"
+	"github.com/stretchr/testify/suite"
+)
+
+type XMeansSuite struct {
+	suite.Suite
+}
+
+func TestXMeansSuite(t *testing.T) {
+	suite.Run(t, new(XMeansSuite))
+}
+
+// fourBlobs returns four well separated 2D Gaussian blobs at irregular positions.
+// A symmetric layout would defeat the first split: halving a square of blobs barely improves the BIC.
+func fourBlobs() []kmeans.Point {
+	return gaussianBlobs([]kmeans.Point{{0, 0}, {15, 2}, {3, 20}, {25, 25}}, 80, 1, 8)
+}
+
+func (s *XMeansSuite) TestFindsFourBlobs() {
+	points := fourBlobs()
+
+	result, err := kmeans.XMeans(points, 1, 10, kmeans.WithSeed(3))
+	s.Require().NoError(err)
+
+	s.Equal(4, result.K)
+	s.Len(result.Centroids, 4)
+	s.Len(result.Assignments, len(points))
+	s.Equal([]int{80, 80, 80, 80}, result.Result.ClusterSizes)
+	s.Greater(len(result.BIC), 1)
+	for i := 1; i < len(result.BIC); i++ {
+		s.Greater(result.BIC[i], result.BIC[i-1])
+	}
+	s.InDelta(kmeans.CalculateBIC(points, result.Centroids, result.Assignments), result.BIC[len(result.BIC)-1], 1e-9)
+}
+
+func (s *XMeansSuite) TestSingleBlobIsNotSplit() {
+	points := gaussianBlobs([]kmeans.Point{{5, 5}}, 200, 1, 4)
+
+	result, err := kmeans.XMeans(points, 1, 8, kmeans.WithSeed(1))
+	s.Require().NoError(err)
+
+	s.Equal(1, result.K)
+	s.Len(result.BIC, 1)
+}
+
+func (s *XMeansSuite) TestStopsAtKMax() {
+	result, err := kmeans.XMeans(fourBlobs(), 1, 3, kmeans.WithSeed(2))
+	s.Require().NoError(err)
+
+	s.Equal(3, result.K)
+	s.Len(result.Centroids, 3)
+}
+
+func (s *XMeansSuite) TestStartsFromKMin() {
+	result, err := kmeans.XMeans(fourBlobs(), 4, 4, kmeans.WithSeed(2))
+	s.Require().NoError(err)
+
+	s.Equal(4, result.K)
+	s.Len(result.BIC, 1)
+}
+
+func (s *XMeansSuite) TestReproducibleWithSeed() {
+	points := fourBlobs()
+
+	first, err := kmeans.XMeans(points, 1, 10, kmeans.WithSeed(9))
+	s.Require().NoError(err)
+	second, err := kmeans.XMeans(points, 1, 10, kmeans.WithSeed(9))
+	s.Require().NoError(err)
+
+	s.Equal(first.Centroids, second.Centroids)
+	s.Equal(first.Assignments, second.Assignments)
+	s.Equal(first.BIC, second.BIC)
+}
+
+func (s *XMeansSuite) TestBICPrefersTheRightModel() {
+	points := []kmeans.Point{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {10, 10}, {10, 11}, {11, 10}, {11, 11}}
+	one := kmeans.CalculateBIC(points, []kmeans.Point{{5.5, 5.5}}, make([]int, 8))
+	two := kmeans.CalculateBIC(points, []kmeans.Point{{0.5, 0.5}, {10.5, 10.5}}, []int{0, 0, 0, 0, 1, 1, 1, 1})
+
+	s.Greater(two, one)
+	s.True(math.IsInf(kmeans.CalculateBIC(points[:2], points[:2], []int{0, 1}), -1))
+}
+
+func (s *XMeansSuite) TestInvalidInputReturnsError() {
+	_, err := kmeans.XMeans(fourBlobs(), 0, 3)
+	s.ErrorIs(err, kmeans.ErrInvalidKRange)
+
+	_, err = kmeans.XMeans(fourBlobs(), 3, 2)
+	s.ErrorIs(err, kmeans.ErrInvalidKRange)
+
+	_, err = kmeans.XMeans(twoBlobs(), 1, 10)
+	s.ErrorIs(err, kmeans.ErrNotEnoughPoints)
+}